@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Distance calculation strategy
+type Algorithm string
+
+const (
+	// Spherical law of cosines, as calculated by Distance
+	CosinesAlgorithm Algorithm = "cosines"
+	// Haversine formula on the mean Earth radius
+	HaversineAlgorithm Algorithm = "haversine"
+	// Vincenty inverse formula on the WGS-84 ellipsoid
+	VincentyAlgorithm Algorithm = "vincenty"
+)
+
+var Algorithms = []string{"cosines", "haversine", "vincenty"}
+
+const (
+	// Mean Earth radius in metres
+	earthMeanRadius float64 = 6371008.8
+	// WGS-84 ellipsoid semi-major axis in metres
+	wgs84A float64 = 6378137.0
+	// WGS-84 ellipsoid flattening
+	wgs84F float64 = 1 / 298.257223563
+	// WGS-84 ellipsoid semi-minor axis in metres
+	wgs84B float64 = (1 - wgs84F) * wgs84A
+)
+
+//  Convert text to Algorithm or return an unknown Algorithm error.
+//
+//  In/
+//  input text to be converted to Algorithm type enumeration, empty text means the law of cosines
+//
+//  The output are the algorithm element and the error, if the algorithm text is not known.
+func ToAlgorithm(in string) (algorithm Algorithm, err error) {
+	switch strings.ToLower(in) {
+	case "", "cosines":
+		algorithm = CosinesAlgorithm
+	case "haversine":
+		algorithm = HaversineAlgorithm
+	case "vincenty":
+		algorithm = VincentyAlgorithm
+	default:
+		algorithm = CosinesAlgorithm
+		err = errors.New(fmt.Sprintf("Unknown distance algorithm text: %s", in))
+	}
+	return algorithm, err
+}
+
+//  Calculates the distance between two points using the algorithm strategy. Any unknown or empty
+//  algorithm uses the spherical law of cosines.
+//
+//  Passed to function/
+//    lat1, lon1 = Latitude and Longitude of point 1 (in decimal degrees)
+//    lat2, lon2 = Latitude and Longitude of point 2 (in decimal degrees)
+//    unit = the unit you desire for results, NaN is returned if the unit is not valid
+//
+func (a Algorithm) Distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64, unit Unit) float64 {
+	switch a {
+	case HaversineAlgorithm:
+		return HaversineDistance(lat1, lng1, lat2, lng2, unit)
+	case VincentyAlgorithm:
+		return VincentyDistance(lat1, lng1, lat2, lng2, unit)
+	default:
+		return Distance(lat1, lng1, lat2, lng2, unit)
+	}
+}
+
+//  This routine calculates the great-circle distance between two points using the Haversine
+//  formula, that is well-conditioned for short distances.
+//
+//  Passed to function/
+//    lat1, lon1 = Latitude and Longitude of point 1 (in decimal degrees)
+//    lat2, lon2 = Latitude and Longitude of point 2 (in decimal degrees)
+//    unit = the unit you desire for results, NaN is returned if the unit is not valid
+//
+func HaversineDistance(lat1 float64, lng1 float64, lat2 float64, lng2 float64, unit Unit) float64 {
+	radlat1 := toRadians(lat1)
+	radlat2 := toRadians(lat2)
+	dlat := radlat2 - radlat1
+	dlng := toRadians(lng2 - lng1)
+
+	h := math.Sin(dlat/2)*math.Sin(dlat/2) + math.Cos(radlat1)*math.Cos(radlat2)*math.Sin(dlng/2)*math.Sin(dlng/2)
+	if h > 1 {
+		h = 1
+	}
+	dist := 2 * earthMeanRadius * math.Asin(math.Sqrt(h))
+
+	return unit.FromMetres(dist)
+}
+
+//  This routine calculates the geodesic distance between two points on the WGS-84 ellipsoid
+//  using the Vincenty inverse formula. Nearly antipodal points, where the formula does not
+//  converge, are calculated with the Karney method on the same ellipsoid.
+//
+//  Passed to function/
+//    lat1, lon1 = Latitude and Longitude of point 1 (in decimal degrees)
+//    lat2, lon2 = Latitude and Longitude of point 2 (in decimal degrees)
+//    unit = the unit you desire for results, NaN is returned if the unit is not valid
+//
+func VincentyDistance(lat1 float64, lng1 float64, lat2 float64, lng2 float64, unit Unit) float64 {
+	dist, converged := vincentyMetres(lat1, lng1, lat2, lng2)
+	if !converged {
+		dist = karneyDistance(lat1, lng1, lat2, lng2)
+	}
+
+	return unit.FromMetres(dist)
+}
+
+// Vincenty inverse formula distance in metres, false if the formula does not converge
+func vincentyMetres(lat1 float64, lng1 float64, lat2 float64, lng2 float64) (float64, bool) {
+	l := toRadians(lng2 - lng1)
+	u1 := math.Atan((1 - wgs84F) * math.Tan(toRadians(lat1)))
+	u2 := math.Atan((1 - wgs84F) * math.Tan(toRadians(lat2)))
+	sinU1, cosU1 := math.Sin(u1), math.Cos(u1)
+	sinU2, cosU2 := math.Sin(u2), math.Cos(u2)
+
+	lambda := l
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	converged := false
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sin(lambda), math.Cos(lambda)
+		sinSigma = math.Sqrt((cosU2*sinLambda)*(cosU2*sinLambda) +
+			(cosU1*sinU2-sinU1*cosU2*cosLambda)*(cosU1*sinU2-sinU1*cosU2*cosLambda))
+		if sinSigma == 0 {
+			// Coincident points
+			return 0, true
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		} else {
+			// Equatorial line
+			cos2SigmaM = 0
+		}
+		c := wgs84F / 16 * cosSqAlpha * (4 + wgs84F*(4-3*cosSqAlpha))
+		previous := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-previous) < 1e-12 {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return math.NaN(), false
+	}
+
+	uSq := cosSqAlpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	return wgs84B * a * (sigma - deltaSigma), true
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToAlgorithm(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Algorithm
+		wantErr bool
+	}{
+		{"Transform empty algorithm text", "", CosinesAlgorithm, false},
+		{"Transform cosines algorithm text", "cosines", CosinesAlgorithm, false},
+		{"Transform case sensitive haversine algorithm text", "Haversine", HaversineAlgorithm, false},
+		{"Transform vincenty algorithm text", "vincenty", VincentyAlgorithm, false},
+		{"Transform incorrect algorithm text", "manhattan", CosinesAlgorithm, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToAlgorithm(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToAlgorithm() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ToAlgorithm() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgorithm_Distance(t *testing.T) {
+	type args struct {
+		lat1 float64
+		lng1 float64
+		lat2 float64
+		lng2 float64
+		unit Unit
+	}
+	// Flinders Peak and Buninyong reference points of the Vincenty formula
+	flindersLat, flindersLng := -(37 + 57/60.0 + 3.72030/3600), 144+25/60.0+29.52440/3600
+	buninyongLat, buninyongLng := -(37 + 39/60.0 + 10.15610/3600), 143+55/60.0+35.38390/3600
+	tests := []struct {
+		name      string
+		algorithm Algorithm
+		args      args
+		want      float64
+		tolerance float64
+	}{
+		{
+			"Calculate cosines distance in Kilometers",
+			CosinesAlgorithm,
+			args{32.9697, -96.80322, 29.46786, -98.53506, "K"},
+			422.738931394014,
+			1e-9,
+		},
+		{
+			"Calculate haversine distance in Kilometers",
+			HaversineAlgorithm,
+			args{32.9697, -96.80322, 29.46786, -98.53506, "K"},
+			422.759854649928,
+			1e-9,
+		},
+		{
+			"Calculate haversine distance in Nautical Miles",
+			HaversineAlgorithm,
+			args{32.9697, -96.80322, 29.46786, -98.53506, "N"},
+			228.27205974618,
+			1e-9,
+		},
+		{
+			"Calculate vincenty distance in Kilometers",
+			VincentyAlgorithm,
+			args{flindersLat, flindersLng, buninyongLat, buninyongLng, "K"},
+			54.972271,
+			1e-6,
+		},
+		{
+			"Calculate vincenty distance of coincident points",
+			VincentyAlgorithm,
+			args{53.339428, -6.257664, 53.339428, -6.257664, "K"},
+			0,
+			0,
+		},
+		{
+			// Vincenty does not converge, reference geodesic of Karney, "Algorithms for geodesics" (2013)
+			"Calculate vincenty distance of nearly antipodal points",
+			VincentyAlgorithm,
+			args{-30, 0, 29.9, 179.8, "K"},
+			19989.832827610,
+			1e-6,
+		},
+		{
+			// Vincenty does not converge, reference geodesic of the GeographicLib test suite
+			"Calculate vincenty distance of nearly antipodal points in Metres",
+			VincentyAlgorithm,
+			args{56.320923501171, 0, -56.320923501171, 179.664747671772880215, "MT"},
+			19993558.287,
+			0.5e-3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.algorithm.Distance(tt.args.lat1, tt.args.lng1, tt.args.lat2, tt.args.lng2, tt.args.unit)
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"math"
+)
+
+//  This routine approximates the circle around a centre point with a closed polygon, whose
+//  vertices are at the given great-circle distance from the centre, on the mean Earth radius.
+//
+//  Passed to function/
+//    lat, lng = Latitude and Longitude of the centre (in decimal degrees)
+//    radius = distance of the vertices from the centre, in the given unit
+//    unit = the unit of the radius
+//    segments = number of polygon sides, at least 3 sides are used
+//
+func Circle(lat float64, lng float64, radius float64, unit Unit, segments int) Polygon {
+	if segments < 3 {
+		segments = 3
+	}
+	// Angular distance of the vertices
+	delta := unit.ToMetres(radius) / earthMeanRadius
+	radlat := toRadians(lat)
+	radlng := toRadians(lng)
+	ring := make([]Point, 0)
+	for i := 0; i < segments; i++ {
+		bearing := 2 * math.Pi * float64(i) / float64(segments)
+		vlat := math.Asin(math.Sin(radlat)*math.Cos(delta) + math.Cos(radlat)*math.Sin(delta)*math.Cos(bearing))
+		vlng := radlng + math.Atan2(math.Sin(bearing)*math.Sin(delta)*math.Cos(radlat),
+			math.Cos(delta)-math.Sin(radlat)*math.Sin(vlat))
+		ring = append(ring, Point{
+			Latitude:  vlat * 180 / math.Pi,
+			Longitude: math.Mod(vlng*180/math.Pi+540, 360) - 180,
+		})
+	}
+	// Closing the ring
+	ring = append(ring, ring[0])
+	return Polygon{Rings: [][]Point{ring}}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCircle(t *testing.T) {
+	tests := []struct {
+		name         string
+		lat, lng     float64
+		radius       float64
+		unit         Unit
+		segments     int
+		wantVertices int
+	}{
+		{"Circle around Dublin in Kilometers", 53.339428, -6.257664, 100, Kilometers, 64, 65},
+		{"Circle crossing the anti-meridian in Miles", -16.5, 179.9, 50, Miles, 16, 17},
+		{"Circle with too few segments", 0, 0, 1000, Metres, 1, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Circle(tt.lat, tt.lng, tt.radius, tt.unit, tt.segments)
+			if len(got.Rings) != 1 || len(got.Rings[0]) != tt.wantVertices {
+				t.Errorf("Circle() = %v, want one ring with %v vertices", got, tt.wantVertices)
+				return
+			}
+			ring := got.Rings[0]
+			if ring[0] != ring[len(ring)-1] {
+				t.Errorf("Circle() ring is not closed: %v", ring)
+			}
+			for _, p := range ring {
+				if d := HaversineDistance(tt.lat, tt.lng, p.Latitude, p.Longitude, tt.unit); math.Abs(d-tt.radius) > tt.radius*1e-9 {
+					t.Errorf("Circle() vertex %v at distance %v, want %v", p, d, tt.radius)
+				}
+				if p.Longitude < -180 || p.Longitude > 180 {
+					t.Errorf("Circle() vertex %v longitude out of range", p)
+				}
+			}
+			if !got.Contains(tt.lat, tt.lng) && tt.lng < 179 {
+				t.Errorf("Circle() does not contain its centre")
+			}
+		})
+	}
+}
@@ -21,11 +21,14 @@ import "math"
 //    lat1, lon1 = Latitude and Longitude of point 1 (in decimal degrees)
 //    lat2, lon2 = Latitude and Longitude of point 2 (in decimal degrees)
 //    unit = the unit you desire for results
-//           where/ 'M' is statute miles (default)
+//           where/ 'M' is statute miles
 //                  'K' is kilometers
 //                  'N' is nautical miles
+//                  or any other geo.Unit
 //
-func Distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64, unit ...string) float64 {
+//  The result is NaN if the unit is not valid.
+//
+func Distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64, unit Unit) float64 {
 	const PI float64 = 3.141592653589793
 
 	radlat1 := float64(PI * lat1 / 180)
@@ -44,13 +47,15 @@ func Distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64, unit ...st
 	dist = dist * 180 / PI
 	dist = dist * 60 * 1.1515
 
-	if len(unit) > 0 {
-		if unit[0] == "K" {
-			dist = dist * 1.609344
-		} else if unit[0] == "N" {
-			dist = dist * 0.8684
-		}
+	// Kilometers and nautical miles factors are kept for compatibility with previous results
+	switch unit {
+	case Miles:
+		return dist
+	case Kilometers:
+		return dist * 1.609344
+	case NauticalMiles:
+		return dist * 0.8684
+	default:
+		return Miles.Convert(dist, unit)
 	}
-
-	return dist
 }
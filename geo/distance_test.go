@@ -16,7 +16,7 @@ func Test_Distance(t *testing.T) {
 		lng1 float64
 		lat2 float64
 		lng2 float64
-		unit []string
+		unit Unit
 	}
 	tests := []struct {
 		name string
@@ -30,7 +30,7 @@ func Test_Distance(t *testing.T) {
 				-96.80322,
 				29.46786,
 				-98.53506,
-				[]string{"M"},
+				"M",
 			},
 			262.677793805435,
 		},
@@ -41,7 +41,7 @@ func Test_Distance(t *testing.T) {
 				-96.80322,
 				29.46786,
 				-98.53506,
-				[]string{"K"},
+				"K",
 			},
 			422.738931394014,
 		},
@@ -52,15 +52,26 @@ func Test_Distance(t *testing.T) {
 				-96.80322,
 				29.46786,
 				-98.53506,
-				[]string{"N"},
+				"N",
 			},
 			228.10939614063972,
 		},
+		{
+			"Calculate coordinates distance in Metres",
+			args{
+				32.9697,
+				-96.80322,
+				29.46786,
+				-98.53506,
+				"MT",
+			},
+			422738.931394014,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := Distance(tt.args.lat1, tt.args.lng1, tt.args.lat2, tt.args.lng2, tt.args.unit...); got != tt.want {
+			if got := Distance(tt.args.lat1, tt.args.lng1, tt.args.lat2, tt.args.lng2, tt.args.unit); got != tt.want {
 				t.Errorf("distance() = %v, want %v", got, tt.want)
 			}
 		})
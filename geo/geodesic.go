@@ -0,0 +1,525 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"math"
+)
+
+// Geodesic distance on the WGS-84 ellipsoid with the method of C. F. F. Karney, "Algorithms for
+// geodesics", J. Geodesy 87 (2013), as implemented by GeographicLib, with series of 6th order in
+// the flattening. It converges for any pair of points, the nearly antipodal ones included.
+
+const (
+	geodesicOrder = 6
+	// Machine epsilon based tolerances of the Newton iterations
+	geodesicTol0 = 2.220446049250313e-16
+	geodesicTol1 = 200 * geodesicTol0
+	// Smallest number whose square root is a normal number
+	geodesicTiny   = 1.4916681462400413e-154
+	geodesicMaxit1 = 20
+	geodesicMaxit2 = geodesicMaxit1 + 53 + 10
+)
+
+var (
+	geodesicTol2    = math.Sqrt(geodesicTol0)
+	geodesicTolb    = geodesicTol0 * geodesicTol2
+	geodesicXthresh = 1000 * geodesicTol2
+	wgs84F1         = 1 - wgs84F
+	wgs84E2         = wgs84F * (2 - wgs84F)
+	// Second eccentricity squared
+	wgs84Ep2 = wgs84E2 / (wgs84F1 * wgs84F1)
+	// Third flattening
+	wgs84N       = wgs84F / (2 - wgs84F)
+	geodesicEtol = 0.1 * geodesicTol2 / math.Sqrt(math.Max(0.001, math.Abs(wgs84F))*math.Min(1, 1-wgs84F/2)/2)
+	// Coefficients of the A3 and C3 series, in the third flattening
+	geodesicA3x = a3Coefficients()
+	geodesicC3x = c3Coefficients()
+)
+
+// Evaluate the polynomial of degree n with the coefficients p, from the highest order one
+func polyval(n int, p []float64, x float64) float64 {
+	if n < 0 {
+		return 0
+	}
+	y := p[0]
+	for i := 1; i <= n; i++ {
+		y = y*x + p[i]
+	}
+	return y
+}
+
+func a3Coefficients() []float64 {
+	coeff := []float64{-3, 128, -2, -3, 64, -1, -3, -1, 16, 3, -1, -2, 8, 1, -1, 2, 1, 1}
+	a3x := make([]float64, 0, geodesicOrder)
+	o := 0
+	for j := geodesicOrder - 1; j >= 0; j-- {
+		m := geodesicOrder - j - 1
+		if j < m {
+			m = j
+		}
+		a3x = append(a3x, polyval(m, coeff[o:], wgs84N)/coeff[o+m+1])
+		o += m + 2
+	}
+	return a3x
+}
+
+func c3Coefficients() []float64 {
+	coeff := []float64{
+		3, 128, 2, 5, 128, -1, 3, 3, 64, -1, 0, 1, 8, -1, 1, 4,
+		5, 256, 1, 3, 128, -3, -2, 3, 64, 1, -3, 2, 32,
+		7, 512, -10, 9, 384, 5, -9, 5, 192,
+		7, 512, -14, 7, 512,
+		21, 2560,
+	}
+	c3x := make([]float64, 0, geodesicOrder*(geodesicOrder-1)/2)
+	o := 0
+	for l := 1; l < geodesicOrder; l++ {
+		for j := geodesicOrder - 1; j >= l; j-- {
+			m := geodesicOrder - j - 1
+			if j < m {
+				m = j
+			}
+			c3x = append(c3x, polyval(m, coeff[o:], wgs84N)/coeff[o+m+1])
+			o += m + 2
+		}
+	}
+	return c3x
+}
+
+func a3f(eps float64) float64 {
+	return polyval(geodesicOrder-1, geodesicA3x, eps)
+}
+
+func c3f(eps float64, c []float64) {
+	mult := 1.0
+	o := 0
+	for l := 1; l < geodesicOrder; l++ {
+		m := geodesicOrder - l - 1
+		mult *= eps
+		c[l] = mult * polyval(m, geodesicC3x[o:], eps)
+		o += m + 1
+	}
+}
+
+func a1m1f(eps float64) float64 {
+	coeff := []float64{1, 4, 64, 0, 256}
+	m := geodesicOrder / 2
+	t := polyval(m, coeff, eps*eps) / coeff[m+1]
+	return (t + eps) / (1 - eps)
+}
+
+func c1f(eps float64, c []float64) {
+	coeff := []float64{-1, 6, -16, 32, -9, 64, -128, 2048, 9, -16, 768, 3, -5, 512, -7, 1280, -7, 2048}
+	seriesCoefficients(coeff, eps, c)
+}
+
+func a2m1f(eps float64) float64 {
+	coeff := []float64{-11, -28, -192, 0, 256}
+	m := geodesicOrder / 2
+	t := polyval(m, coeff, eps*eps) / coeff[m+1]
+	return (t - eps) / (1 + eps)
+}
+
+func c2f(eps float64, c []float64) {
+	coeff := []float64{1, 2, 16, 32, 35, 64, 384, 2048, 15, 80, 768, 7, 35, 512, 63, 1280, 77, 2048}
+	seriesCoefficients(coeff, eps, c)
+}
+
+// Fill the C1 or C2 series coefficients, polynomials in eps^2 multiplied by eps^l
+func seriesCoefficients(coeff []float64, eps float64, c []float64) {
+	eps2 := eps * eps
+	d := eps
+	o := 0
+	for l := 1; l <= geodesicOrder; l++ {
+		m := (geodesicOrder - l) / 2
+		c[l] = d * polyval(m, coeff[o:], eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+}
+
+// Evaluate the sine series sum(c[l] * sin(2 * l * x)) with the Clenshaw summation
+func sinSeries(sinx float64, cosx float64, c []float64) float64 {
+	k := len(c)
+	n := k - 1
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	var y0, y1 float64
+	if n&1 != 0 {
+		k--
+		y0 = c[k]
+	}
+	for n /= 2; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+	return 2 * sinx * cosx * y0
+}
+
+func norm2(x float64, y float64) (float64, float64) {
+	r := math.Hypot(x, y)
+	return x / r, y / r
+}
+
+// Sum of the numbers and its rounding error
+func sumError(u float64, v float64) (float64, float64) {
+	s := u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+	return s, -(up + vpp)
+}
+
+func angNormalize(x float64) float64 {
+	y := math.Remainder(x, 360)
+	if y == -180 {
+		return 180
+	}
+	return y
+}
+
+// Longitude difference in (-180, 180], with its rounding error
+func angDiff(x float64, y float64) (float64, float64) {
+	d, t := sumError(angNormalize(-x), angNormalize(y))
+	d = angNormalize(d)
+	if d == 180 && t > 0 {
+		d = -180
+	}
+	return sumError(d, t)
+}
+
+// Round the tiny angles, so they are exact multiples of 1/16 degree
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	if x == 0 {
+		return 0
+	}
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+	return math.Copysign(y, x)
+}
+
+// Sine and cosine of the angle in degrees, exact for the multiples of 90 degrees
+func sincosd(x float64) (float64, float64) {
+	r := math.Mod(x, 360)
+	q := int(math.Round(r / 90))
+	r = (r - 90*float64(q)) * math.Pi / 180
+	s, c := math.Sin(r), math.Cos(r)
+	switch ((q % 4) + 4) % 4 {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+	if x == 0 {
+		s = x
+	}
+	return s, c + 0
+}
+
+// Solution of the astroid equation, for the starting point of the nearly antipodal points
+func astroid(x float64, y float64) float64 {
+	p := x * x
+	q := y * y
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+	u := r
+	if disc >= 0 {
+		t3 := s + r3
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+		t := math.Cbrt(t3)
+		u += t
+		if t != 0 {
+			u += r2 / t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(u*u + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+// Geodesic line of the auxiliary sphere between the reduced latitudes, for given azimuth
+type geodesicLine struct {
+	sbet1, cbet1, dn1 float64
+	sbet2, cbet2, dn2 float64
+	c1a, c2a, c3a     []float64
+}
+
+// Distance and reduced length, in units of the semi-minor axis
+func (g *geodesicLine) lengths(eps float64, sig12 float64, ssig1 float64, csig1 float64, ssig2 float64, csig2 float64) (s12b float64, m12b float64) {
+	a1 := a1m1f(eps)
+	c1f(eps, g.c1a)
+	a2 := a2m1f(eps)
+	c2f(eps, g.c2a)
+	m0x := a1 - a2
+	a1++
+	a2++
+	b1 := sinSeries(ssig2, csig2, g.c1a) - sinSeries(ssig1, csig1, g.c1a)
+	b2 := sinSeries(ssig2, csig2, g.c2a) - sinSeries(ssig1, csig1, g.c2a)
+	s12b = a1 * (sig12 + b1)
+	j12 := m0x*sig12 + (a1*b1 - a2*b2)
+	m12b = g.dn2*(csig1*ssig2) - g.dn1*(ssig1*csig2) - csig1*csig2*j12
+	return s12b, m12b
+}
+
+// Starting azimuth of the Newton iterations, or the arc length of the short lines solved directly
+func (g *geodesicLine) inverseStart(lam12 float64, slam12 float64, clam12 float64) (sig12 float64, salp1 float64, calp1 float64, dnm float64) {
+	sig12 = -1
+	sbet1, cbet1, sbet2, cbet2 := g.sbet1, g.cbet1, g.sbet2, g.cbet2
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+	var somg12, comg12 float64
+	if shortline {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		dnm = math.Sqrt(1 + wgs84Ep2*sbetm2)
+		omg12 := lam12 / (wgs84F1 * dnm)
+		somg12, comg12 = math.Sin(omg12), math.Cos(omg12)
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+	switch {
+	case shortline && ssig12 < geodesicEtol:
+		sig12 = math.Atan2(ssig12, csig12)
+	case math.Abs(wgs84N) >= 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(wgs84N)*math.Pi*cbet1*cbet1:
+		// Nothing to do, the zeroth order spherical approximation is fine
+	default:
+		// Nearly antipodal points, starting from the astroid solution
+		lam12x := math.Atan2(-slam12, -clam12)
+		k2 := sbet1 * sbet1 * wgs84Ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		lamscale := wgs84F * cbet1 * a3f(eps) * math.Pi
+		betscale := lamscale * cbet1
+		x := lam12x / lamscale
+		y := sbet12a / betscale
+		if y > -geodesicTol1 && x > -1-geodesicXthresh {
+			salp1 = math.Min(1, -x)
+			calp1 = -math.Sqrt(1 - salp1*salp1)
+		} else {
+			k := astroid(x, y)
+			omg12a := lamscale * (-x * k / (1 + k))
+			somg12, comg12 = math.Sin(omg12a), -math.Cos(omg12a)
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+	if salp1 > 0 {
+		salp1, calp1 = norm2(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+	return sig12, salp1, calp1, dnm
+}
+
+// Longitude difference error of the azimuth, with its derivative, and the arc of the line
+type lambdaResult struct {
+	v, dv        float64
+	sig12        float64
+	ssig1, csig1 float64
+	ssig2, csig2 float64
+	eps          float64
+}
+
+func (g *geodesicLine) lambda12(salp1 float64, calp1 float64, slam120 float64, clam120 float64, diffp bool) lambdaResult {
+	sbet1, cbet1, sbet2, cbet2 := g.sbet1, g.cbet1, g.sbet2, g.cbet2
+	if sbet1 == 0 && calp1 == 0 {
+		calp1 = -geodesicTiny
+	}
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+	ssig1, csig1 := norm2(sbet1, calp1*cbet1)
+	somg1, comg1 := salp0*sbet1, calp1*cbet1
+	var calp2 float64
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var d float64
+		if cbet1 < -sbet1 {
+			d = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			d = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+d) / cbet2
+	} else {
+		calp2 = math.Abs(calp1)
+	}
+	ssig2, csig2 := norm2(sbet2, calp2*cbet2)
+	somg2, comg2 := salp0*sbet2, calp2*cbet2
+	sig12 := math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+	k2 := calp0 * calp0 * wgs84Ep2
+	eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	c3f(eps, g.c3a)
+	b312 := sinSeries(ssig2, csig2, g.c3a) - sinSeries(ssig1, csig1, g.c3a)
+	domg12 := -wgs84F * a3f(eps) * salp0 * (sig12 + b312)
+	r := lambdaResult{v: eta + domg12, sig12: sig12, ssig1: ssig1, csig1: csig1, ssig2: ssig2, csig2: csig2, eps: eps}
+	if diffp {
+		if calp2 == 0 {
+			r.dv = -2 * wgs84F1 * g.dn1 / sbet1
+		} else {
+			_, m12b := g.lengths(eps, sig12, ssig1, csig1, ssig2, csig2)
+			r.dv = m12b * wgs84F1 / (calp2 * cbet2)
+		}
+	}
+	return r
+}
+
+// Reduced latitude sine and cosine of the geodetic latitude
+func reducedLatitude(lat float64) (float64, float64) {
+	sbet, cbet := sincosd(lat)
+	sbet, cbet = norm2(sbet*wgs84F1, cbet)
+	return sbet, math.Max(geodesicTiny, cbet)
+}
+
+// Geodesic distance in metres between two points on the WGS-84 ellipsoid, NaN for latitudes
+// out of the [-90, 90] degrees range
+func karneyDistance(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	if math.Abs(lat1) > 90 || math.Abs(lat2) > 90 {
+		return math.NaN()
+	}
+	lon12, lon12s := angDiff(lng1, lng2)
+	lonsign := 1.0
+	if lon12 < 0 {
+		lonsign = -1
+	}
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180 - lon12) - lonsign*lon12s)
+	lam12 := lon12 * math.Pi / 180
+	var slam12, clam12 float64
+	if lon12 > 90 {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+	lat1, lat2 = angRound(lat1), angRound(lat2)
+	// The first point is the one farther from the equator, in the southern hemisphere
+	if math.Abs(lat1) < math.Abs(lat2) {
+		lat1, lat2 = lat2, lat1
+	}
+	if !(lat1 < 0) {
+		lat1, lat2 = -lat1, -lat2
+	}
+	g := &geodesicLine{
+		c1a: make([]float64, geodesicOrder+1),
+		c2a: make([]float64, geodesicOrder+1),
+		c3a: make([]float64, geodesicOrder),
+	}
+	g.sbet1, g.cbet1 = reducedLatitude(lat1)
+	g.sbet2, g.cbet2 = reducedLatitude(lat2)
+	if g.cbet1 < -g.sbet1 {
+		if g.cbet2 == g.cbet1 {
+			g.sbet2 = math.Copysign(g.sbet1, g.sbet2)
+		}
+	} else if math.Abs(g.sbet2) == -g.sbet1 {
+		g.cbet2 = g.cbet1
+	}
+	g.dn1 = math.Sqrt(1 + wgs84Ep2*g.sbet1*g.sbet1)
+	g.dn2 = math.Sqrt(1 + wgs84Ep2*g.sbet2*g.sbet2)
+
+	meridian := lat1 == -90 || slam12 == 0
+	if meridian {
+		// Along the meridian, the azimuth is the longitude difference
+		ssig1, csig1 := g.sbet1, clam12*g.cbet1
+		ssig2, csig2 := g.sbet2, g.cbet2
+		sig12 := math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12x, m12x := g.lengths(wgs84N, sig12, ssig1, csig1, ssig2, csig2)
+		if sig12 < 1 || m12x >= 0 {
+			if sig12 < 3*geodesicTiny {
+				return 0
+			}
+			return s12x * wgs84B
+		}
+		// The meridian is not the shortest path, e.g. between the points near the poles
+	}
+	if g.sbet1 == 0 && lon12s >= wgs84F*180 {
+		// Along the equator
+		return wgs84A * lam12
+	}
+	sig12, salp1, calp1, dnm := g.inverseStart(lam12, slam12, clam12)
+	if sig12 >= 0 {
+		// Short line on the sphere with the mean radius of the points
+		return sig12 * wgs84B * dnm
+	}
+	// Newton iterations on the azimuth, falling back to the bisection of the bracket
+	salp1a, calp1a := geodesicTiny, 1.0
+	salp1b, calp1b := geodesicTiny, -1.0
+	tripn, tripb := false, false
+	var r lambdaResult
+	for numit := 0; numit < geodesicMaxit2; {
+		r = g.lambda12(salp1, calp1, slam12, clam12, numit < geodesicMaxit1)
+		tol := geodesicTol0
+		if tripn {
+			tol *= 8
+		}
+		if tripb || !(math.Abs(r.v) >= tol) {
+			break
+		}
+		if r.v > 0 && (numit > geodesicMaxit1 || calp1/salp1 > calp1b/salp1b) {
+			salp1b, calp1b = salp1, calp1
+		} else if r.v < 0 && (numit > geodesicMaxit1 || calp1/salp1 < calp1a/salp1a) {
+			salp1a, calp1a = salp1, calp1
+		}
+		numit++
+		if numit < geodesicMaxit1 && r.dv > 0 {
+			dalp1 := -r.v / r.dv
+			sdalp1, cdalp1 := math.Sin(dalp1), math.Cos(dalp1)
+			nsalp1 := salp1*cdalp1 + calp1*sdalp1
+			if nsalp1 > 0 && math.Abs(dalp1) < math.Pi {
+				calp1 = calp1*cdalp1 - salp1*sdalp1
+				salp1 = nsalp1
+				salp1, calp1 = norm2(salp1, calp1)
+				tripn = math.Abs(r.v) <= 16*geodesicTol0
+				continue
+			}
+		}
+		salp1, calp1 = norm2((salp1a+salp1b)/2, (calp1a+calp1b)/2)
+		tripn = false
+		tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < geodesicTolb ||
+			math.Abs(salp1-salp1b)+(calp1-calp1b) < geodesicTolb
+	}
+	s12x, _ := g.lengths(r.eps, r.sig12, r.ssig1, r.csig1, r.ssig2, r.csig2)
+	return s12x * wgs84B
+}
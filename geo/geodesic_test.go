@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_karneyDistance(t *testing.T) {
+	type args struct {
+		lat1 float64
+		lng1 float64
+		lat2 float64
+		lng2 float64
+	}
+	// Flinders Peak and Buninyong reference points of the Vincenty formula
+	flindersLat, flindersLng := -(37 + 57/60.0 + 3.72030/3600), 144+25/60.0+29.52440/3600
+	buninyongLat, buninyongLng := -(37 + 39/60.0 + 10.15610/3600), 143+55/60.0+35.38390/3600
+	// Reference geodesics of Karney, "Algorithms for geodesics" (2013), and of the GeographicLib
+	// test suite, in metres
+	tests := []struct {
+		name      string
+		args      args
+		want      float64
+		tolerance float64
+	}{
+		{"Calculate Flinders Peak to Buninyong geodesic", args{flindersLat, flindersLng, buninyongLat, buninyongLng}, 54972.271, 0.5e-3},
+		{"Calculate JFK to CDG geodesic", args{40.6, -73.8, 49.01666667, 2.55}, 5853226.256, 0.5e-3},
+		{"Calculate nearly antipodal geodesic", args{-30, 0, 29.9, 179.8}, 19989832.827610, 1e-6},
+		{"Calculate nearly antipodal geodesic at mid latitudes", args{48.522876735459, 0, -48.52287673545898293, 179.599720456223079643}, 19989144.774, 0.5e-3},
+		{"Calculate nearly antipodal geodesic at high latitudes", args{88.202499451857, 0, -88.202499451857, 179.981022032992859592}, 20003898.214, 0.5e-3},
+		{"Calculate antipodal equator points geodesic, over the poles", args{0, 0, 0, 180}, 20003931.458625, 1e-6},
+		{"Calculate pole to pole geodesic", args{90, 0, -90, 0}, 20003931.458625, 1e-6},
+		{"Calculate coincident points geodesic", args{53.339428, -6.257664, 53.339428, -6.257664}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := karneyDistance(tt.args.lat1, tt.args.lng1, tt.args.lat2, tt.args.lng2)
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("karneyDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := karneyDistance(91, 0, 0, 0); !math.IsNaN(got) {
+		t.Errorf("karneyDistance() = %v, want NaN for invalid latitude", got)
+	}
+}
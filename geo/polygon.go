@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Geographic point in decimal degrees
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Polygon area, where the first ring is the outer border and the following ones are holes
+type Polygon struct {
+	Rings [][]Point
+}
+
+// Area made of one or more polygons, as the catchment area of an event
+type Geofence struct {
+	Polygons []Polygon
+}
+
+//  Verifies if a point falls inside the polygon, using the even-odd ray casting rule, so points
+//  inside a hole are outside the polygon.
+//
+//  Passed to function/
+//    lat, lng = Latitude and Longitude of the point (in decimal degrees)
+//
+func (p Polygon) Contains(lat float64, lng float64) bool {
+	if len(p.Rings) == 0 || !ringContains(p.Rings[0], lat, lng) {
+		return false
+	}
+	for _, hole := range p.Rings[1:] {
+		if ringContains(hole, lat, lng) {
+			return false
+		}
+	}
+	return true
+}
+
+//  Verifies if a point falls inside any of the geofence polygons
+//
+//  Passed to function/
+//    lat, lng = Latitude and Longitude of the point (in decimal degrees)
+//
+func (g *Geofence) Contains(lat float64, lng float64) bool {
+	for _, polygon := range g.Polygons {
+		if polygon.Contains(lat, lng) {
+			return true
+		}
+	}
+	return false
+}
+
+func ringContains(ring []Point, lat float64, lng float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		pi, pj := ring[i], ring[j]
+		if (pi.Latitude > lat) != (pj.Latitude > lat) &&
+			lng < (pj.Longitude-pi.Longitude)*(lat-pi.Latitude)/(pj.Latitude-pi.Latitude)+pi.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Minimal GeoJSON object, for geometries, features and feature collections
+type geoJsonObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometry    *geoJsonObject  `json:"geometry,omitempty"`
+	Features    []geoJsonObject `json:"features,omitempty"`
+}
+
+//  Read a geofence from a GeoJSON document, containing a Polygon or MultiPolygon geometry,
+//  also wrapped in a Feature or a FeatureCollection.
+//
+//  Data/
+//  bytes of the GeoJSON document, with positions in [longitude, latitude] order
+//
+//  The output are the geofence and the error, if the document is not a valid polygon document.
+func ReadGeofence(data []byte) (*Geofence, error) {
+	var object geoJsonObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	geofence := &Geofence{Polygons: make([]Polygon, 0)}
+	if err := geofence.add(object); err != nil {
+		return nil, err
+	}
+	if len(geofence.Polygons) == 0 {
+		return nil, errors.New("No polygon found in GeoJSON document")
+	}
+	return geofence, nil
+}
+
+func (g *Geofence) add(object geoJsonObject) error {
+	switch object.Type {
+	case "Polygon":
+		var coordinates [][][]float64
+		if err := json.Unmarshal(object.Coordinates, &coordinates); err != nil {
+			return err
+		}
+		polygon, err := toPolygon(coordinates)
+		if err != nil {
+			return err
+		}
+		g.Polygons = append(g.Polygons, polygon)
+	case "MultiPolygon":
+		var coordinates [][][][]float64
+		if err := json.Unmarshal(object.Coordinates, &coordinates); err != nil {
+			return err
+		}
+		for _, polygonCoordinates := range coordinates {
+			polygon, err := toPolygon(polygonCoordinates)
+			if err != nil {
+				return err
+			}
+			g.Polygons = append(g.Polygons, polygon)
+		}
+	case "Feature":
+		if object.Geometry == nil {
+			return errors.New("Missing geometry in GeoJSON Feature")
+		}
+		return g.add(*object.Geometry)
+	case "FeatureCollection":
+		for _, feature := range object.Features {
+			if err := g.add(feature); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.New(fmt.Sprintf("Unsupported GeoJSON type: %s", object.Type))
+	}
+	return nil
+}
+
+func toPolygon(coordinates [][][]float64) (Polygon, error) {
+	polygon := Polygon{Rings: make([][]Point, 0)}
+	if len(coordinates) == 0 {
+		return polygon, errors.New("Empty GeoJSON Polygon coordinates")
+	}
+	for _, ringCoordinates := range coordinates {
+		if len(ringCoordinates) < 4 {
+			return polygon, errors.New(fmt.Sprintf("GeoJSON Polygon ring must have at least 4 positions, found %v", len(ringCoordinates)))
+		}
+		ring := make([]Point, 0)
+		for _, position := range ringCoordinates {
+			if len(position) < 2 {
+				return polygon, errors.New(fmt.Sprintf("Invalid GeoJSON position: %v", position))
+			}
+			ring = append(ring, Point{Latitude: position[1], Longitude: position[0]})
+		}
+		polygon.Rings = append(polygon.Rings, ring)
+	}
+	return polygon, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"testing"
+)
+
+// County Dublin like square, with a hole around the city centre
+const testPolygon = `{"type": "Polygon", "coordinates": [
+	[[-6.6, 53.2], [-6.0, 53.2], [-6.0, 53.6], [-6.6, 53.6], [-6.6, 53.2]],
+	[[-6.3, 53.3], [-6.2, 53.3], [-6.2, 53.4], [-6.3, 53.4], [-6.3, 53.3]]
+]}`
+
+const testFeatureCollection = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"name": "Cork"}, "geometry": {"type": "MultiPolygon", "coordinates": [
+		[[[-8.6, 51.8], [-8.3, 51.8], [-8.3, 52.0], [-8.6, 52.0], [-8.6, 51.8]]],
+		[[[-9.2, 53.2], [-8.9, 53.2], [-8.9, 53.4], [-9.2, 53.4], [-9.2, 53.2]]]
+	]}}
+]}`
+
+func TestReadGeofence(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantPolygons int
+		wantErr      bool
+	}{
+		{"Read GeoJSON Polygon", testPolygon, 1, false},
+		{"Read GeoJSON FeatureCollection with MultiPolygon", testFeatureCollection, 2, false},
+		{"Read GeoJSON Point", `{"type": "Point", "coordinates": [-6.2, 53.3]}`, 0, true},
+		{"Read GeoJSON unclosed Polygon", `{"type": "Polygon", "coordinates": [[[-6.6, 53.2], [-6.0, 53.2], [-6.6, 53.2]]]}`, 0, true},
+		{"Read GeoJSON Feature without geometry", `{"type": "Feature"}`, 0, true},
+		{"Read invalid JSON", `{"type": `, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadGeofence([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadGeofence() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil && len(got.Polygons) != tt.wantPolygons {
+				t.Errorf("ReadGeofence() got %v polygons, want %v", len(got.Polygons), tt.wantPolygons)
+			}
+		})
+	}
+}
+
+func TestGeofence_Contains(t *testing.T) {
+	polygon, err := ReadGeofence([]byte(testPolygon))
+	if err != nil {
+		t.Errorf("ReadGeofence() error = %v", err)
+		return
+	}
+	multiPolygon, err := ReadGeofence([]byte(testFeatureCollection))
+	if err != nil {
+		t.Errorf("ReadGeofence() error = %v", err)
+		return
+	}
+	tests := []struct {
+		name     string
+		geofence *Geofence
+		lat      float64
+		lng      float64
+		want     bool
+	}{
+		{"Point inside the polygon", polygon, 53.5, -6.4, true},
+		{"Point inside the polygon hole", polygon, 53.339428, -6.257664, false},
+		{"Point outside the polygon", polygon, 51.9, -8.4, false},
+		{"Point inside the first polygon", multiPolygon, 51.9, -8.4, true},
+		{"Point inside the second polygon", multiPolygon, 53.27, -9.05, true},
+		{"Point outside all polygons", multiPolygon, 53.5, -6.4, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.geofence.Contains(tt.lat, tt.lng); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Distance measure unit
+type Unit string
+
+const (
+	Kilometers    Unit = "K"
+	Miles         Unit = "M"
+	NauticalMiles Unit = "N"
+	Metres        Unit = "MT"
+	Feet          Unit = "FT"
+	Yards         Unit = "YD"
+)
+
+var Units = []string{"K", "M", "N", "MT", "FT", "YD"}
+
+// Length of each unit in metres
+var unitMetres = map[Unit]float64{
+	Kilometers:    1000,
+	Miles:         1609.344,
+	NauticalMiles: 1852,
+	Metres:        1,
+	Feet:          0.3048,
+	Yards:         0.9144,
+}
+
+//  Convert text to Unit or return an unknown Unit error. Text is case insensitive and accepts
+//  the unit symbols and names, where 'M' stands for statute miles.
+//
+//  In/
+//  input text to be converted to Unit type enumeration
+//
+//  The output are the unit element and the error, if the unit text is not known.
+func ParseUnit(in string) (unit Unit, err error) {
+	switch strings.ToLower(strings.TrimSpace(in)) {
+	case "k", "km", "kilometers", "kilometres":
+		unit = Kilometers
+	case "m", "mi", "miles":
+		unit = Miles
+	case "n", "nm", "nmi", "nautical-miles":
+		unit = NauticalMiles
+	case "mt", "meters", "metres":
+		unit = Metres
+	case "ft", "feet":
+		unit = Feet
+	case "yd", "yards":
+		unit = Yards
+	default:
+		err = errors.New(fmt.Sprintf("Unknown distance unit text: %s, available units: %v", in, Units))
+	}
+	return unit, err
+}
+
+// Verify that the unit is one of the known units
+func (u Unit) IsValid() bool {
+	_, ok := unitMetres[u]
+	return ok
+}
+
+// Verify that the unit is one of the known units, reporting an error otherwise
+func (u Unit) Validate() error {
+	if !u.IsValid() {
+		return errors.New(fmt.Sprintf("Invalid distance unit: '%s', available units: %v", u, Units))
+	}
+	return nil
+}
+
+//  Convert a distance value from the unit to the target unit
+//
+//  Value/
+//  distance value expressed in the unit
+//
+//  To/
+//  target unit of the conversion
+//
+//  The output is the converted value, or NaN if any of the units is not valid.
+func (u Unit) Convert(value float64, to Unit) float64 {
+	return to.FromMetres(u.ToMetres(value))
+}
+
+// Convert a distance value expressed in the unit to metres, or NaN if the unit is not valid
+func (u Unit) ToMetres(value float64) float64 {
+	if factor, ok := unitMetres[u]; ok {
+		return value * factor
+	}
+	return math.NaN()
+}
+
+// Convert a distance value expressed in metres to the unit, or NaN if the unit is not valid
+func (u Unit) FromMetres(value float64) float64 {
+	if factor, ok := unitMetres[u]; ok {
+		return value / factor
+	}
+	return math.NaN()
+}
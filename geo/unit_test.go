@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Unit
+		wantErr bool
+	}{
+		{"Parse kilometers symbol", "K", Kilometers, false},
+		{"Parse lowercase kilometers symbol", "k", Kilometers, false},
+		{"Parse miles name", "Miles", Miles, false},
+		{"Parse nautical miles symbol", "nm", NauticalMiles, false},
+		{"Parse metres name", "metres", Metres, false},
+		{"Parse feet symbol", "ft", Feet, false},
+		{"Parse yards symbol", "YD", Yards, false},
+		{"Parse empty unit", "", "", true},
+		{"Parse unknown unit", "parsec", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUnit(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseUnit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseUnit() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		unit    Unit
+		wantErr bool
+	}{
+		{"Validate kilometers", Kilometers, false},
+		{"Validate feet", Feet, false},
+		{"Validate lowercase unit", "k", true},
+		{"Validate empty unit", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.unit.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := tt.unit.IsValid(); got == tt.wantErr {
+				t.Errorf("IsValid() = %v, want %v", got, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnit_Convert(t *testing.T) {
+	tests := []struct {
+		name  string
+		from  Unit
+		value float64
+		to    Unit
+		want  float64
+	}{
+		{"Convert kilometers to metres", Kilometers, 1.5, Metres, 1500},
+		{"Convert miles to yards", Miles, 1, Yards, 1760},
+		{"Convert nautical miles to kilometers", NauticalMiles, 10, Kilometers, 18.52},
+		{"Convert yards to feet", Yards, 2, Feet, 6},
+		{"Convert miles to miles", Miles, 3.5, Miles, 3.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.Convert(tt.value, tt.to); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Convert() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := Unit("X").Convert(1, Metres); !math.IsNaN(got) {
+		t.Errorf("Convert() = %v, want NaN for invalid unit", got)
+	}
+}
@@ -5,11 +5,15 @@ go 1.14
 require (
 	github.com/aws/aws-sdk-go v1.33.16 // indirect
 	github.com/drnic/go-greatcircle v0.0.0-20170717034738-1ccc6160f267 // indirect
+	github.com/golang/protobuf v1.4.2
 	github.com/google/uuid v1.1.1
 	github.com/hellgate75/go-services v0.0.1 // indirect
 	github.com/klauspost/compress v1.10.10 // indirect
+	github.com/pkg/sftp v1.11.0
 	go.mongodb.org/mongo-driver v1.4.0 // indirect
-	golang.org/x/crypto v0.0.0-20200728195943-123391ffb6de // indirect
+	golang.org/x/crypto v0.0.0-20200728195943-123391ffb6de
 	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208 // indirect
+	google.golang.org/grpc v1.31.0
+	google.golang.org/protobuf v1.25.0
 	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hellgate75/go-invite-customers/model"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Default max size of the received datagrams, the max udp payload size
+const DefaultMaxDatagramSize = 65507
+
+// Error of a datagram larger than the max datagram size, whose records are discarded
+type DatagramSizeError struct {
+	MaxSize int
+}
+
+func (e *DatagramSizeError) Error() string {
+	return fmt.Sprintf("datagram larger than the max size of %v bytes, its records are discarded", e.MaxSize)
+}
+
+// Reader of the records of the udp datagrams, one or more lines per datagram
+type DatagramReader struct {
+	conn        net.PacketConn
+	buff        []byte
+	idleTimeout time.Duration
+	endMessage  string
+	done        bool
+}
+
+//  Create the reader of the datagrams received by the packet connection.
+//
+//  Conn/
+//  Udp connection, dialed or listening, that is not closed by the reader
+//
+//  MaxSize/
+//  Max size of the datagrams, zero or less for DefaultMaxDatagramSize
+//
+//  IdleTimeout/
+//  Time without any datagram after which the stream ends, zero for no timeout
+//
+//  EndMessage/
+//  Line ending the stream when received, none if empty
+//
+//  The output is the datagram reader.
+func NewDatagramReader(conn net.PacketConn, maxSize int, idleTimeout time.Duration, endMessage string) *DatagramReader {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDatagramSize
+	}
+	return &DatagramReader{
+		conn: conn,
+		// The extra byte detects the datagrams larger than the max size, truncated by the read
+		buff:        make([]byte, maxSize+1),
+		idleTimeout: idleTimeout,
+		endMessage:  strings.TrimSpace(endMessage),
+	}
+}
+
+//  Read the records of the next datagram, its lines without the line terminators. Empty
+//  datagrams only restart the idle timeout.
+//
+//  The output are the records and the error: io.EOF at the end message or after the idle
+//  timeout, a *DatagramSizeError for a datagram larger than the max size, that is discarded
+//  while the following ones can still be read, or the connection error.
+func (r *DatagramReader) ReadRecords() (records [][]byte, err error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	n := 0
+	for n == 0 {
+		if r.idleTimeout > 0 {
+			_ = r.conn.SetReadDeadline(time.Now().Add(r.idleTimeout))
+		}
+		if n, _, err = r.conn.ReadFrom(r.buff); err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				r.done = true
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+	}
+	if n == len(r.buff) {
+		return nil, &DatagramSizeError{MaxSize: len(r.buff) - 1}
+	}
+	data := bytes.TrimSuffix(r.buff[:n], []byte{'\n'})
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+		if r.endMessage != "" && string(bytes.TrimSpace(line)) == r.endMessage {
+			// The records before the end message are still returned
+			r.done = true
+			if len(records) == 0 {
+				return nil, io.EOF
+			}
+			return records, nil
+		}
+		// The buffer is reused by the next read
+		records = append(records, append([]byte{}, line...))
+	}
+	return records, nil
+}
+
+// Read the records of the datagrams received by the connection, one or more lines per datagram,
+// until the end message or the idle timeout of the input data
+func readDatagrams(ctx context.Context, conn net.PacketConn, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+	r := NewDatagramReader(conn, inputData.MaxDatagramSize, inputData.IdleTimeout, inputData.EndMessage)
+	decoder, err := newRecordDecoder(inputData)
+	if err != nil {
+		errCh <- err
+		return
+	}
+	// Whole document of the list input, parsed at the end of the stream
+	var document bytes.Buffer
+	for {
+		records, err := r.ReadRecords()
+		var sizeError *DatagramSizeError
+		if errors.As(err, &sizeError) {
+			errCh <- &model.StreamError{Source: redactedUrl(inputData.FileOrStream), Err: err}
+			continue
+		}
+		if err != nil {
+			reportStreamError(ctx, inputData, err, errCh)
+			break
+		}
+		for _, record := range records {
+			if !inputData.UsePerLineInput {
+				document.Write(record)
+				document.WriteByte('\n')
+				continue
+			}
+			customer, skip, errP := decoder.decode(record)
+			if errP != nil {
+				errCh <- errP
+			} else if !skip && !sendCustomer(ctx, customer, ch) {
+				return
+			}
+		}
+	}
+	if !inputData.UsePerLineInput && ctx.Err() == nil {
+		parseAndServerList(ctx, &document, inputData, ch, errCh)
+	}
+}
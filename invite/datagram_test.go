@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"context"
+	"errors"
+	io2 "github.com/hellgate75/go-invite-customers/io"
+	"github.com/hellgate75/go-invite-customers/model"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Open a listening udp connection and a client sending the datagrams to it
+func sendDatagrams(t *testing.T, datagrams []string) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	go func() {
+		defer func() {
+			_ = client.Close()
+		}()
+		for _, datagram := range datagrams {
+			_, _ = client.Write([]byte(datagram))
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	return conn
+}
+
+func TestDatagramReader_ReadRecords(t *testing.T) {
+	tests := []struct {
+		name        string
+		datagrams   []string
+		maxSize     int
+		idleTimeout time.Duration
+		endMessage  string
+		// Records of each read, joined by commas, or the read error
+		want []string
+	}{
+		{
+			name:       "Test datagrams records until end message",
+			datagrams:  []string{"a1\na2\n", "", "b1\r\n", "c1\nEND\nc2", "d1"},
+			endMessage: "END",
+			want:       []string{"a1,a2", "b1", "c1", "EOF", "EOF"},
+		},
+		{
+			name:       "Test end message datagram",
+			datagrams:  []string{"a1", " END \n"},
+			endMessage: "END",
+			want:       []string{"a1", "EOF"},
+		},
+		{
+			name:        "Test datagrams records until idle timeout",
+			datagrams:   []string{"a1", "b1"},
+			idleTimeout: 200 * time.Millisecond,
+			want:        []string{"a1", "b1", "EOF"},
+		},
+		{
+			name:       "Test datagram larger than max size",
+			datagrams:  []string{"0123456789", "0123456789\n", "012345678", "END"},
+			maxSize:    10,
+			endMessage: "END",
+			want:       []string{"0123456789", "size", "012345678", "EOF"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := sendDatagrams(t, tt.datagrams)
+			defer func() {
+				_ = conn.Close()
+			}()
+			reader := NewDatagramReader(conn, tt.maxSize, tt.idleTimeout, tt.endMessage)
+			for i, want := range tt.want {
+				records, err := reader.ReadRecords()
+				got := make([]string, 0)
+				for _, record := range records {
+					got = append(got, string(record))
+				}
+				var sizeError *DatagramSizeError
+				switch {
+				case err == io.EOF:
+					got = []string{"EOF"}
+				case errors.As(err, &sizeError):
+					got = []string{"size"}
+					if sizeError.MaxSize != tt.maxSize {
+						t.Errorf("ReadRecords() max size = %v, want %v", sizeError.MaxSize, tt.maxSize)
+					}
+				case err != nil:
+					t.Errorf("ReadRecords() error = %v", err)
+					return
+				}
+				if strings.Join(got, ",") != want {
+					t.Errorf("ReadRecords() read %v = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_readDatagrams(t *testing.T) {
+	thomas := "{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}"
+	michael := "{\"latitude\": \"51.903614\", \"user_id\": 1, \"name\": \"Michael Barret\", \"longitude\": \"-8.468399\"}"
+	tests := []struct {
+		name          string
+		datagrams     []string
+		perLine       bool
+		maxSize       int
+		wantCustomers int
+		wantErrors    []string
+	}{
+		{
+			name:          "Test per line datagrams",
+			datagrams:     []string{thomas + "\n" + michael, "{bad}", "END"},
+			perLine:       true,
+			wantCustomers: 2,
+			wantErrors:    []string{"parse"},
+		},
+		{
+			name:          "Test list datagrams",
+			datagrams:     []string{"{\"customers\": [" + thomas + ",", michael + "]}", "END"},
+			perLine:       false,
+			wantCustomers: 2,
+		},
+		{
+			name:          "Test truncated datagram",
+			datagrams:     []string{thomas, strings.Repeat("x", 200), michael, "END"},
+			perLine:       true,
+			maxSize:       150,
+			wantCustomers: 2,
+			wantErrors:    []string{"stream"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := sendDatagrams(t, tt.datagrams)
+			defer func() {
+				_ = conn.Close()
+			}()
+			inputData := InputData{
+				FileOrStream:    "udp-listen://" + conn.LocalAddr().String(),
+				InputEncoding:   io2.JsonEncoding,
+				UsePerLineInput: tt.perLine,
+				IdleTimeout:     5 * time.Second,
+				EndMessage:      "END",
+				MaxDatagramSize: tt.maxSize,
+			}
+			ch := make(chan model.CustomerOffice, 10)
+			errCh := make(chan error, 10)
+			readDatagrams(context.Background(), conn, inputData, ch, errCh)
+			close(ch)
+			close(errCh)
+			if len(ch) != tt.wantCustomers {
+				t.Errorf("readDatagrams() customers = %v, want %v", len(ch), tt.wantCustomers)
+			}
+			errs := make([]string, 0)
+			for err := range errCh {
+				errs = append(errs, string(model.ToErrorRecord(err).Kind))
+			}
+			if strings.Join(errs, ",") != strings.Join(tt.wantErrors, ",") {
+				t.Errorf("readDatagrams() errors = %v, want %v", errs, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"context"
+	"github.com/hellgate75/go-invite-customers/model"
+)
+
+// Filter of the customer records sharing the same user id, accordingly to the duplicate policy
+type deduplicator struct {
+	policy model.DuplicatePolicy
+	sink   ResultSink
+	errCh  chan error
+	// Number of records sharing the user id of a previous record
+	duplicates int
+	// Duplicate records dropped by the first and last wins policies, which are not scan errors
+	dropped []error
+}
+
+//  Forward the customers of the input channel to the returned channel, applying the duplicate
+//  policy. The first wins policy forwards the customers as they arrive, keeping only the seen
+//  user ids. The last wins and error policies cannot decide before the end of the input, so
+//  they hold all the customer records in memory and forward them when the input ends: with
+//  these policies the sink receives no decision before the end of the input, and a listen
+//  input stream must be bounded by its idle timeout or end message. The returned channel is
+//  closed after the last customer and the last duplicate error, so the duplicates count and
+//  the dropped records can be read after it is drained.
+func (d *deduplicator) run(ctx context.Context, in chan model.CustomerOffice) chan model.CustomerOffice {
+	out := make(chan model.CustomerOffice, cap(in))
+	go func() {
+		defer close(out)
+		if d.policy == model.KeepFirstDuplicate || d.policy == "" {
+			d.keepFirst(ctx, in, out)
+		} else {
+			d.collect(ctx, in, out)
+		}
+	}()
+	return out
+}
+
+func (d *deduplicator) keepFirst(ctx context.Context, in chan model.CustomerOffice, out chan model.CustomerOffice) {
+	seen := make(map[int64]bool)
+	for customer := range in {
+		if seen[customer.UserId] {
+			d.report(customer, model.KeepFirstDuplicate)
+			continue
+		}
+		seen[customer.UserId] = true
+		if !sendCustomer(ctx, customer, out) {
+			// Scan cancelled, draining the customers already read
+			for range in {
+			}
+			return
+		}
+	}
+}
+
+func (d *deduplicator) collect(ctx context.Context, in chan model.CustomerOffice, out chan model.CustomerOffice) {
+	// Customers are forwarded in the order of their first record
+	order := make([]int64, 0)
+	records := make(map[int64][]model.CustomerOffice)
+	for customer := range in {
+		if _, ok := records[customer.UserId]; !ok {
+			order = append(order, customer.UserId)
+		}
+		records[customer.UserId] = append(records[customer.UserId], customer)
+	}
+	for _, userId := range order {
+		list := records[userId]
+		if len(list) > 1 && d.policy == model.RejectDuplicates {
+			for _, customer := range list {
+				err := d.report(customer, model.RejectDuplicates)
+				d.sink.Rejected(customer, err)
+			}
+			// Only the records after the first one are counted as duplicates
+			d.duplicates--
+			continue
+		}
+		for _, customer := range list[:len(list)-1] {
+			d.report(customer, model.KeepLastDuplicate)
+		}
+		if !sendCustomer(ctx, list[len(list)-1], out) {
+			return
+		}
+	}
+}
+
+// Report a duplicate record, as scan error only when the policy rejects the duplicates
+func (d *deduplicator) report(customer model.CustomerOffice, policy model.DuplicatePolicy) error {
+	d.duplicates++
+	err := &model.DuplicateError{
+		UserId: customer.UserId,
+		Name:   customer.Name,
+		Policy: policy,
+	}
+	if policy == model.RejectDuplicates {
+		d.errCh <- err
+	} else {
+		d.dropped = append(d.dropped, err)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"context"
+	"errors"
+	"github.com/hellgate75/go-invite-customers/model"
+	"testing"
+)
+
+func Test_executeScan_duplicates(t *testing.T) {
+	customers := []model.CustomerOffice{
+		{UserId: 1, Name: "Thomas Barret", Latitude: "53.339111", Longitude: "-6.257611"},
+		{UserId: 2, Name: "Michael Barret", Latitude: "53.339111", Longitude: "-6.257611"},
+		{UserId: 1, Name: "Thomas Barrett", Latitude: "50.339428", Longitude: "-3.257664"},
+		{UserId: 1, Name: "Tom Barrett", Latitude: "53.339111", Longitude: "-6.257611"},
+	}
+	generator := func(ctx context.Context, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+		defer close(ch)
+		for _, customer := range customers {
+			if !sendCustomer(ctx, customer, ch) {
+				return
+			}
+		}
+	}
+	tests := []struct {
+		name           string
+		policy         model.DuplicatePolicy
+		wantInvited    map[int64]string
+		wantExcluded   int
+		wantRejected   int
+		wantDuplicates int
+		wantDropped    int
+		wantErrors     int
+	}{
+		{
+			name:           "Test first record wins by default",
+			policy:         "",
+			wantInvited:    map[int64]string{1: "Thomas Barret", 2: "Michael Barret"},
+			wantDuplicates: 2,
+			wantDropped:    2,
+		},
+		{
+			name:           "Test last record wins",
+			policy:         model.KeepLastDuplicate,
+			wantInvited:    map[int64]string{1: "Tom Barrett", 2: "Michael Barret"},
+			wantDuplicates: 2,
+			wantDropped:    2,
+		},
+		{
+			name:           "Test duplicate records are rejected",
+			policy:         model.RejectDuplicates,
+			wantInvited:    map[int64]string{2: "Michael Barret"},
+			wantRejected:   3,
+			wantDuplicates: 2,
+			wantErrors:     3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, errs := executeScan(context.Background(), InputData{
+				UseDetailedOutput: true,
+				Distance:          100,
+				MeasureUnit:       "K",
+				HomeLongitude:     -6.257664,
+				HomeLatitude:      53.339428,
+				DuplicatePolicy:   tt.policy,
+			}, generator)
+			invited := make(map[int64]string)
+			for _, c := range out.Complete.MatchingCustomerIds {
+				invited[c.UserId] = c.Name
+			}
+			if len(invited) != len(out.Complete.MatchingCustomerIds) || len(invited) != len(tt.wantInvited) {
+				t.Errorf("executeScan() invited = %+v, want %v", out.Complete.MatchingCustomerIds, tt.wantInvited)
+			}
+			for userId, name := range tt.wantInvited {
+				if invited[userId] != name {
+					t.Errorf("executeScan() invited [%v] = %v, want %v", userId, invited[userId], name)
+				}
+			}
+			if len(out.Complete.RejectedCustomerIds) != tt.wantRejected {
+				t.Errorf("executeScan() rejected = %+v, want %v customers", out.Complete.RejectedCustomerIds, tt.wantRejected)
+			}
+			if out.Duplicates != tt.wantDuplicates {
+				t.Errorf("executeScan() duplicates = %v, want %v", out.Duplicates, tt.wantDuplicates)
+			}
+			if len(errs) != tt.wantErrors {
+				t.Errorf("executeScan() errs = %v, want %v errors", errs, tt.wantErrors)
+			}
+			if len(out.DroppedDuplicates) != tt.wantDropped {
+				t.Errorf("executeScan() dropped duplicates = %v, want %v", out.DroppedDuplicates, tt.wantDropped)
+			}
+			if out.IsDone != (tt.wantErrors == 0) {
+				t.Errorf("executeScan() IsDone = %v, want %v", out.IsDone, tt.wantErrors == 0)
+			}
+			for _, err := range append(errs, out.DroppedDuplicates...) {
+				var duplicateError *model.DuplicateError
+				if !errors.As(err, &duplicateError) || duplicateError.UserId != 1 {
+					t.Errorf("executeScan() error = %v, want duplicate error of customer 1", err)
+				}
+			}
+		})
+	}
+}
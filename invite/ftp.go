@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	url2 "net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables of the ftp and sftp input credentials, used when the url has none
+const (
+	FtpUserEnv      = "INVITE_FTP_USER"
+	FtpPasswordEnv  = "INVITE_FTP_PASSWORD"
+	SftpUserEnv     = "INVITE_SFTP_USER"
+	SftpPasswordEnv = "INVITE_SFTP_PASSWORD"
+	// Private key file of the sftp public key authentication, used with or instead of the password
+	SftpKeyFileEnv = "INVITE_SFTP_KEY_FILE"
+	// Known hosts file verifying the sftp server keys, $HOME/.ssh/known_hosts if not set
+	SftpKnownHostsEnv = "INVITE_SFTP_KNOWN_HOSTS"
+)
+
+// Timeout of the ftp and sftp server connections
+const remoteDialTimeout = 30 * time.Second
+
+// Timeout of the ftp transfer completion and logout replies
+const ftpCloseTimeout = 5 * time.Second
+
+// Address of the ftp passive mode data connection
+var ftpPassiveRegexp = regexp.MustCompile(`(\d+),(\d+),(\d+),(\d+),(\d+),(\d+)`)
+
+// User information of an url that cannot be parsed
+var urlUserRegexp = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]*://)[^/]*@`)
+
+// Get the url with its password masked, to report the input source without the credentials
+func redactedUrl(rawUrl string) string {
+	u, err := url2.Parse(rawUrl)
+	if err != nil {
+		return urlUserRegexp.ReplaceAllString(rawUrl, "${1}xxxxx@")
+	}
+	if _, ok := u.User.Password(); !ok {
+		return rawUrl
+	}
+	u.User = url2.UserPassword(u.User.Username(), "xxxxx")
+	return u.String()
+}
+
+// Parse the remote file url of the given scheme, adding the default port to its host
+func parseRemoteUrl(remoteUrl string, scheme string, defaultPort string) (*url2.URL, error) {
+	u, err := url2.Parse(remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != scheme || u.Hostname() == "" || u.Path == "" || u.Path == "/" {
+		return nil, errors.New(fmt.Sprintf("Invalid %s url: %s", scheme, redactedUrl(remoteUrl)))
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	return u, nil
+}
+
+// Get the user and password of the url, or of the given environment variables if the url has no user
+func remoteCredentials(u *url2.URL, userEnv string, passwordEnv string) (user string, password string) {
+	if u.User != nil && u.User.Username() != "" {
+		password, _ = u.User.Password()
+		return u.User.Username(), password
+	}
+	return os.Getenv(userEnv), os.Getenv(passwordEnv)
+}
+
+// Verify if the text has control characters, which would end or split an ftp command line
+func hasControlChars(text string) bool {
+	for _, r := range text {
+		if r < 0x20 || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// Ftp file download, over the passive mode data connection
+type ftpStream struct {
+	conn net.Conn
+	text *textproto.Conn
+	data net.Conn
+	// Set when the data connection reached the end of file, with the transfer completion error
+	done bool
+	err  error
+}
+
+//  Open the file of the ftp server at given url, downloading it in binary mode over a passive
+//  mode data connection.
+//
+//  FtpUrl/
+//  Input url (ftp://[user[:password]@]host[:port]/path => ftp://files.example.com/customers.txt),
+//  without user the INVITE_FTP_USER and INVITE_FTP_PASSWORD environment variables are used, or
+//  the anonymous login if they are not set. As for RFC 1738 the path is relative to the login
+//  directory, an absolute path starts with %2F (ftp://files.example.com/%2Fdata/customers.txt)
+//
+//  The output are the file reader (to close, completing the download) and the error, if any
+//  error occurs during the login or the download request.
+func OpenFtpStream(ftpUrl string) (io.ReadCloser, error) {
+	if ftpUrl == "" {
+		return nil, errors.New(fmt.Sprint("Empty ftp url"))
+	}
+	u, err := parseRemoteUrl(ftpUrl, "ftp", "21")
+	if err != nil {
+		return nil, err
+	}
+	user, password := remoteCredentials(u, FtpUserEnv, FtpPasswordEnv)
+	if user == "" {
+		user, password = "anonymous", "anonymous"
+	}
+	// The url path separator from the host is not part of the file path
+	path := strings.TrimPrefix(u.Path, "/")
+	if hasControlChars(user) || hasControlChars(password) || hasControlChars(path) {
+		return nil, errors.New("Invalid ftp user, password or path: control characters are not allowed")
+	}
+	conn, err := net.DialTimeout("tcp", u.Host, remoteDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	f := &ftpStream{conn: conn, text: textproto.NewConn(conn)}
+	if err = f.download(user, password, path); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Login and request the file, opening the data connection
+func (f *ftpStream) download(user string, password string, path string) error {
+	_ = f.conn.SetDeadline(time.Now().Add(remoteDialTimeout))
+	if _, _, err := f.text.ReadResponse(220); err != nil {
+		return err
+	}
+	code, _, err := f.cmd(0, "USER %s", user)
+	if err != nil {
+		return err
+	}
+	if code == 331 {
+		if code, _, err = f.cmd(0, "PASS %s", password); err != nil {
+			return err
+		}
+	}
+	if code != 230 && code != 202 {
+		return errors.New(fmt.Sprintf("Ftp login of user %s refused with code %v", user, code))
+	}
+	if _, _, err = f.cmd(200, "TYPE I"); err != nil {
+		return err
+	}
+	_, msg, err := f.cmd(227, "PASV")
+	if err != nil {
+		return err
+	}
+	match := ftpPassiveRegexp.FindStringSubmatch(msg)
+	if match == nil {
+		return errors.New(fmt.Sprintf("Invalid ftp passive mode reply: %s", msg))
+	}
+	p1, _ := strconv.Atoi(match[5])
+	p2, _ := strconv.Atoi(match[6])
+	// The data connection uses the control connection host, as the reply address can be private
+	host, _, _ := net.SplitHostPort(f.conn.RemoteAddr().String())
+	data, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(p1<<8+p2)), remoteDialTimeout)
+	if err != nil {
+		return err
+	}
+	if _, _, err = f.cmd(1, "RETR %s", path); err != nil {
+		_ = data.Close()
+		return err
+	}
+	// The control connection is idle during the transfer
+	_ = f.conn.SetDeadline(time.Time{})
+	f.data = data
+	return nil
+}
+
+// Send the command and read its reply, with the expected code, or code class, if not zero
+func (f *ftpStream) cmd(expectCode int, format string, args ...interface{}) (code int, msg string, err error) {
+	id, err := f.text.Cmd(format, args...)
+	if err != nil {
+		return 0, "", err
+	}
+	f.text.StartResponse(id)
+	defer f.text.EndResponse(id)
+	return f.text.ReadResponse(expectCode)
+}
+
+func (f *ftpStream) Read(p []byte) (int, error) {
+	if f.done {
+		if f.err != nil {
+			return 0, f.err
+		}
+		return 0, io.EOF
+	}
+	n, err := f.data.Read(p)
+	if err == io.EOF {
+		// The file is complete only if the server confirms the transfer, e.g. not on 426 or 451 replies
+		f.done = true
+		_ = f.conn.SetDeadline(time.Now().Add(ftpCloseTimeout))
+		if _, _, errR := f.text.ReadResponse(2); errR != nil {
+			f.err = errors.New(fmt.Sprintf("Ftp transfer not completed: %v", errR))
+			err = f.err
+		}
+	}
+	return n, err
+}
+
+func (f *ftpStream) Close() error {
+	var err error
+	_ = f.conn.SetDeadline(time.Now().Add(ftpCloseTimeout))
+	if f.data != nil {
+		err = f.data.Close()
+		if f.done {
+			if f.err != nil {
+				err = f.err
+			}
+		} else {
+			// Transfer aborted by a partial read
+			_, _, _ = f.text.ReadResponse(0)
+		}
+	}
+	_, _, _ = f.cmd(221, "QUIT")
+	if errC := f.text.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
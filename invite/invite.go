@@ -11,20 +11,36 @@ package invite
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
-	"fmt"
 	"github.com/hellgate75/go-invite-customers/geo"
 	"github.com/hellgate75/go-invite-customers/io"
 	"github.com/hellgate75/go-invite-customers/model"
 	io2 "io"
+	"math"
+	"net"
+	"regexp"
+	"runtime"
+	"strconv"
+	"sync"
 	"time"
 )
 
+// Line of the yaml decoding errors
+var yamlLineRegexp = regexp.MustCompile(`line (\d+)`)
+
 type OutputData struct {
 	Simple     *model.InviteList
 	Complete   *model.CompleteInviteList
 	IsComplete bool
 	IsDone     bool
+	// Number of customer records sharing the user id of a previous record
+	Duplicates int
+	// Duplicate errors of the records dropped by the first and last wins policies, reported
+	// apart from the scan errors since the scan is not failed by them
+	DroppedDuplicates []error
 }
 
 type InputData struct {
@@ -32,15 +48,78 @@ type InputData struct {
 	HomeLatitude      float64
 	HomeLongitude     float64
 	Distance          float64
-	MeasureUnit       string
+	MeasureUnit       geo.Unit
 	InputEncoding     io.Encoding
 	UsePerLineInput   bool
 	UseDetailedOutput bool
 	SilentOutput      bool
 	OutputEncoding    io.Encoding
+	// Number of concurrent customer evaluation workers, zero or less uses the number of CPUs
+	WorkerPoolSize int
+	// Receiver of the decisions, if any the output data lists are left empty
+	Sink ResultSink
+	// Order of the output data lists, applied when the scan ends
+	SortOrder model.SortOrder
+	// Distance calculation algorithm, the spherical law of cosines if empty
+	Algorithm geo.Algorithm
+	// Catchment area, if any invites the customers inside it instead of the ones within distance
+	Geofence *geo.Geofence
+	// Event venues, if any invites the customers to the nearest venue within its radius, instead
+	// of using the home coordinates, the distance and the geofence
+	Venues []model.Venue
+	// Columns of the csv and tsv input encodings, the default columns if empty
+	CsvMapping io.CsvMapping
+	// Policy of the customer records sharing the same user id, first wins if empty. The last wins
+	// and error policies hold all the records in memory until the end of the input
+	DuplicatePolicy model.DuplicatePolicy
+	// Time without any received record after which the udp, udp-listen and tcp-listen input
+	// streams end, zero for no timeout
+	IdleTimeout time.Duration
+	// Line ending the udp, udp-listen and tcp-listen input streams when received, none if empty
+	EndMessage string
+	// Max size of the udp and udp-listen input datagrams, DefaultMaxDatagramSize if zero or less
+	MaxDatagramSize int
+}
+
+// Decoder of the line by line input records, tracking their position in the stream
+type recordDecoder struct {
+	enc io.Encoding
+	csv *io.CsvDecoder
+	// Position of the current line in the stream, for the parse errors
+	lineNumber int
+	offset     int64
+}
+
+func newRecordDecoder(inputData InputData) (*recordDecoder, error) {
+	d := &recordDecoder{enc: inputData.InputEncoding}
+	if isDelimitedEncoding(inputData.InputEncoding) {
+		var err error
+		if d.csv, err = io.NewCsvDecoder(inputData.InputEncoding, inputData.CsvMapping); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// Decode the customer of the next line, skip reports the lines without a customer, e.g. the csv header
+func (d *recordDecoder) decode(line []byte) (customer model.CustomerOffice, skip bool, err error) {
+	d.lineNumber++
+	if d.csv != nil {
+		// The decoder reports its own parse errors
+		customer, skip, err = d.csv.DecodeLine(line)
+	} else if customer, err = io.ReadCustomerOffice(line, d.enc); err != nil {
+		err = model.NewParseError(d.lineNumber, d.offset, line, err)
+	}
+	d.offset += int64(len(line)) + 1
+	return customer, skip, err
 }
 
-func readLineByLine(r io2.Reader, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+func readLineByLine(ctx context.Context, r io2.Reader, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+	decoder, err := newRecordDecoder(inputData)
+	if err != nil {
+		errCh <- err
+		return
+	}
 	br := bufio.NewReader(r)
 	buff := bytes.NewBuffer([]byte{})
 	line, isPref, err := br.ReadLine()
@@ -53,183 +132,484 @@ func readLineByLine(r io2.Reader, inputData InputData, ch chan model.CustomerOff
 				line = buff.Bytes()
 				buff.Reset()
 			}
-			customer, errP := io.ReadCustomerOffice(line, inputData.InputEncoding)
+			customer, skip, errP := decoder.decode(line)
 			if errP != nil {
 				errCh <- errP
-			} else {
-				ch <- customer
+			} else if !skip && !sendCustomer(ctx, customer, ch) {
+				return
 			}
 		}
 		line, isPref, err = br.ReadLine()
 	}
+	reportStreamError(ctx, inputData, err, errCh)
 }
-func parseAndServerList(r io2.Reader, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+
+func parseAndServerList(ctx context.Context, r io2.Reader, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
 	br := bufio.NewReader(r)
 	buff := bytes.NewBuffer([]byte{})
-	line, _, err := br.ReadLine()
+	line, isPref, err := br.ReadLine()
 	for err == nil || len(line) > 0 {
 		buff.Write(line)
-		line, _, err = br.ReadLine()
+		if !isPref {
+			// Keeping the line terminators, for the line oriented encodings
+			buff.WriteByte('\n')
+		}
+		line, isPref, err = br.ReadLine()
 	}
+	reportStreamError(ctx, inputData, err, errCh)
 	data := buff.Bytes()
-	list, err := io.ReadCustomerOfficeList(data, inputData.InputEncoding)
-	if err != nil {
-		errCh <- err
-		return
+	var list model.CustomerOfficeList
+	if isDelimitedEncoding(inputData.InputEncoding) {
+		var errs []error
+		list, errs = io.ReadCustomerOfficeCsv(data, inputData.InputEncoding, inputData.CsvMapping)
+		for _, errX := range errs {
+			errCh <- errX
+		}
+	} else {
+		list, err = io.ReadCustomerOfficeList(data, inputData.InputEncoding)
+		if err != nil {
+			errCh <- newDocumentParseError(data, err)
+			return
+		}
 	}
 	for _, customer := range list.List {
-		ch <- customer
+		if !sendCustomer(ctx, customer, ch) {
+			return
+		}
 	}
 }
 
-func createChannelWriterFunc(url string) (function func(InputData, chan model.CustomerOffice, chan error), err error) {
-	if url[:6] == "udp://" {
+// Report the stream reading errors, unless the stream has been closed by the scan cancellation
+func reportStreamError(ctx context.Context, inputData InputData, err error, errCh chan error) {
+	if err != nil && err != io2.EOF && ctx.Err() == nil {
+		errCh <- &model.StreamError{Source: redactedUrl(inputData.FileOrStream), Err: err}
+	}
+}
+
+// Create the parse error of a whole document, locating the error when the decoder reports it
+func newDocumentParseError(data []byte, err error) *model.ParseError {
+	var offset int64 = -1
+	var line int
+	var jsonSyntaxError *json.SyntaxError
+	var jsonTypeError *json.UnmarshalTypeError
+	var xmlSyntaxError *xml.SyntaxError
+	if errors.As(err, &jsonSyntaxError) {
+		offset = jsonSyntaxError.Offset
+	} else if errors.As(err, &jsonTypeError) {
+		offset = jsonTypeError.Offset
+	} else if errors.As(err, &xmlSyntaxError) {
+		line = xmlSyntaxError.Line
+	} else if match := yamlLineRegexp.FindStringSubmatch(err.Error()); match != nil {
+		line, _ = strconv.Atoi(match[1])
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset >= 0 {
+		// Line containing the offset
+		line = bytes.Count(data[:offset], []byte("\n")) + 1
+	}
+	if line <= 0 {
+		return model.NewParseError(0, 0, nil, err)
+	}
+	// Locating the line start and reporting the line as snippet
+	start := 0
+	for i := 1; i < line && start < len(data); i++ {
+		next := bytes.IndexByte(data[start:], '\n')
+		if next < 0 {
+			start = len(data)
+			break
+		}
+		start += next + 1
+	}
+	end := bytes.IndexByte(data[start:], '\n')
+	if end < 0 {
+		end = len(data) - start
+	}
+	return model.NewParseError(line, int64(start), data[start:start+end], err)
+}
+
+// Verify if the encoding is a csv or tsv one, decoded with the input data columns mapping
+func isDelimitedEncoding(enc io.Encoding) bool {
+	return enc == io.CsvEncoding || enc == io.TsvEncoding
+}
+
+// Send the customer to the evaluation channel, unless the scan has been cancelled
+func sendCustomer(ctx context.Context, customer model.CustomerOffice, ch chan model.CustomerOffice) bool {
+	select {
+	case ch <- customer:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func createChannelWriterFunc(url string, input InputData) (function func(context.Context, InputData, chan model.CustomerOffice, chan error), err error) {
+	var closer io2.Closer
+	var reader io2.Reader
+	// Connection of the udp streams, read a datagram at a time
+	var packetConn net.PacketConn
+	// The url credentials are not reported in the errors
+	var source = redactedUrl(url)
+	switch toStreamScheme(url) {
+	case udpScheme:
 		// Udp protocol
-		c, r, err := OpenUdpStream(url)
+		c, _, err := OpenUdpStream(url)
 		if err != nil {
-			return function, err
-		}
-		function = func(inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
-			defer func() {
-				// Close the udp connection
-				if c != nil {
-					_ = c.Close()
-				}
-			}()
-			if inputData.UsePerLineInput {
-				readLineByLine(r, inputData, ch, errCh)
-			} else {
-				parseAndServerList(r, inputData, ch, errCh)
-			}
+			return function, &model.StreamError{Source: source, Err: err}
 		}
-	} else if url[:6] == "tcp://" {
+		closer, packetConn = c, c.(net.PacketConn)
+	case tcpScheme:
 		// Tcp protocol
 		c, r, err := OpenTcpStream(url)
 		if err != nil {
-			return function, err
+			return function, &model.StreamError{Source: source, Err: err}
 		}
-		function = func(inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
-			defer func() {
-				// Close the tcp connection
-				if c != nil {
-					_ = c.Close()
-				}
-			}()
-			if inputData.UsePerLineInput {
-				readLineByLine(r, inputData, ch, errCh)
-			} else {
-				parseAndServerList(r, inputData, ch, errCh)
-			}
+		closer, reader = c, r
+	case udpListenScheme:
+		// Udp server, receiving the datagrams of the clients
+		c, err := ListenUdpStream(url)
+		if err != nil {
+			return function, &model.StreamError{Source: source, Err: err}
+		}
+		closer, packetConn = c, c
+	case tcpListenScheme:
+		// Tcp server, receiving the connections of the clients
+		s, err := ListenTcpStream(url, input.IdleTimeout, input.EndMessage)
+		if err != nil {
+			return function, &model.StreamError{Source: source, Err: err}
 		}
-	} else if url[:7] == "http://" || url[:8] == "https://" || url[:6] == "ftp://" || url[:7] == "sftp://" {
-		// Http / Ftp protocol
+		closer, reader = s, s
+	case httpScheme:
+		// Http protocol
 		re, r, err := OpenUrlStream(url)
 		if err != nil {
-			return function, err
+			return function, &model.StreamError{Source: source, Err: err}
 		}
-		function = func(inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
-			defer func() {
-				// Close the http get body
-				if re != nil {
-					_ = re.Body.Close()
-				}
-			}()
-			if inputData.UsePerLineInput {
-				readLineByLine(r, inputData, ch, errCh)
-			} else {
-				parseAndServerList(r, inputData, ch, errCh)
-			}
+		closer, reader = re.Body, r
+	case ftpScheme:
+		// Ftp protocol
+		s, err := OpenFtpStream(url)
+		if err != nil {
+			return function, &model.StreamError{Source: source, Err: err}
 		}
-	} else {
+		closer, reader = s, s
+	case sftpScheme:
+		// Sftp protocol
+		s, err := OpenSftpStream(url)
+		if err != nil {
+			return function, &model.StreamError{Source: source, Err: err}
+		}
+		closer, reader = s, s
+	default:
 		// file protocol
 		f, err := OpenFileStream(url)
 		if err != nil {
-			return function, err
+			return function, &model.StreamError{Source: source, Err: err}
 		}
-		function = func(inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
-			defer func() {
-				// Close the file
-				if f != nil {
-					_ = f.Close()
-				}
-			}()
-			if inputData.UsePerLineInput {
-				readLineByLine(f, inputData, ch, errCh)
-			} else {
-				parseAndServerList(f, inputData, ch, errCh)
+		closer, reader = f, f
+	}
+	function = func(ctx context.Context, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+		var once sync.Once
+		var closeStream = func() {
+			// Close the connection, the http get body or the file
+			once.Do(func() {
+				_ = closer.Close()
+			})
+		}
+		var finished = make(chan struct{})
+		defer func() {
+			close(finished)
+			closeStream()
+			// Signal the end of the stream to the customers collector
+			close(ch)
+		}()
+		go func() {
+			// Closing the stream interrupts any pending read on cancellation
+			select {
+			case <-ctx.Done():
+				closeStream()
+			case <-finished:
 			}
+		}()
+		if packetConn != nil {
+			readDatagrams(ctx, packetConn, inputData, ch, errCh)
+		} else if inputData.UsePerLineInput {
+			readLineByLine(ctx, reader, inputData, ch, errCh)
+		} else {
+			parseAndServerList(ctx, reader, inputData, ch, errCh)
 		}
 	}
 	return function, err
 }
 
+//  Execute the invitation scan reading the customers from the given input stream and collecting
+//  the invited (and excluded, if detailed output is required) customers.
+//
+//  Input/
+//  Input data that describes the stream, the home coordinates and the distance criteria
+//
+//  The call returns when the stream is exhausted and all customers have been evaluated, so the
+//  output data is final. The output are the output data and the errors arisen during the scan.
 func ExecuteInviteScan(input InputData) (out OutputData, errs []error) {
-	out = OutputData{
+	return ExecuteInviteScanWithContext(context.Background(), input)
+}
+
+//  Execute the invitation scan as ExecuteInviteScan does, stopping it when the given context is
+//  cancelled or expires.
+//
+//  Ctx/
+//  Context that controls the scan life-cycle
+//
+//  Input/
+//  Input data that describes the stream, the home coordinates and the distance criteria
+//
+//  On cancellation the input stream is closed, the pending evaluations are completed and the
+//  partial output data is returned as not done, together with the context error.
+func ExecuteInviteScanWithContext(ctx context.Context, input InputData) (out OutputData, errs []error) {
+	if err := input.MeasureUnit.Validate(); err != nil {
+		out = newOutputData(input)
+		return out, []error{err}
+	}
+	fn, err := createChannelWriterFunc(input.FileOrStream, input)
+	if err != nil {
+		out = newOutputData(input)
+		return out, []error{err}
+	}
+	return executeScan(ctx, input, fn)
+}
+
+//  Execute the invitation scan as ExecuteInviteScanWithContext does, reading the customers from
+//  the given reader instead of the input data file or stream.
+//
+//  Ctx/
+//  Context that controls the scan life-cycle
+//
+//  R/
+//  Reader of the customers, in the input data encoding, it is not closed by the scan
+//
+//  Input/
+//  Input data that describes the encoding, the home coordinates and the distance criteria, the
+//  file or stream is ignored
+//
+//  The output are the output data and the errors arisen during the scan.
+func ExecuteInviteScanFromReader(ctx context.Context, r io2.Reader, input InputData) (out OutputData, errs []error) {
+	if err := input.MeasureUnit.Validate(); err != nil {
+		out = newOutputData(input)
+		return out, []error{err}
+	}
+	return executeScan(ctx, input, func(ctx context.Context, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+		// Signal the end of the stream to the customers collector
+		defer close(ch)
+		if inputData.UsePerLineInput {
+			readLineByLine(ctx, r, inputData, ch, errCh)
+		} else {
+			parseAndServerList(ctx, r, inputData, ch, errCh)
+		}
+	})
+}
+
+//  Execute the invitation scan as ExecuteInviteScanWithContext does, evaluating the customers
+//  received from the given channel instead of the input data file or stream.
+//
+//  Ctx/
+//  Context that controls the scan life-cycle
+//
+//  Customers/
+//  Channel of the customers, the scan ends when it is closed by the caller
+//
+//  Input/
+//  Input data that describes the home coordinates and the distance criteria, the file or stream
+//  and the encodings are ignored
+//
+//  The output are the output data and the errors arisen during the scan.
+func ExecuteInviteScanFromChannel(ctx context.Context, customers <-chan model.CustomerOffice, input InputData) (out OutputData, errs []error) {
+	if err := input.MeasureUnit.Validate(); err != nil {
+		out = newOutputData(input)
+		return out, []error{err}
+	}
+	return executeScan(ctx, input, func(ctx context.Context, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+		// Signal the end of the stream to the customers collector
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case customer, ok := <-customers:
+				if !ok || !sendCustomer(ctx, customer, ch) {
+					return
+				}
+			}
+		}
+	})
+}
+
+//  Encode the scan output data in the input data output encoding, reporting the arisen error.
+//
+//  Out/
+//  Output data of the scan
+//
+//  Input/
+//  Input data of the scan, the GeoJSON encoding reports its home coordinates and distance, or
+//  its venues, and its geofence
+//
+//  The output are the byte array and the error, if occurred during the encoding operations.
+func EncodeOutputData(out OutputData, input InputData) (data []byte, err error) {
+	if input.OutputEncoding == io.GeoJsonEncoding {
+		layout := io.GeoJsonLayout{
+			Centres:  input.Venues,
+			Unit:     input.MeasureUnit,
+			Geofence: input.Geofence,
+		}
+		if len(input.Venues) == 0 {
+			home := model.Venue{Name: "home", Latitude: input.HomeLatitude, Longitude: input.HomeLongitude, Radius: input.Distance}
+			if input.Geofence != nil {
+				// The geofence replaces the distance criteria
+				home.Radius = 0
+			}
+			layout.Centres = []model.Venue{home}
+		}
+		return io.EncodeCustomerGeoJson(out.Complete, layout)
+	}
+	if out.IsComplete {
+		return io.EncodeCompleteInviteList(out.Complete, input.OutputEncoding)
+	}
+	return io.EncodeInviteList(out.Simple, input.OutputEncoding)
+}
+
+func newOutputData(input InputData) OutputData {
+	out := OutputData{
 		Simple:     model.NewInviteList(),
 		Complete:   model.NewCompleteInviteList(),
 		IsComplete: input.UseDetailedOutput,
 	}
+	// Venues groups follow the input venues order
+	for _, venue := range input.Venues {
+		if out.IsComplete {
+			out.Complete.AddVenue(venue.Name)
+		} else {
+			out.Simple.AddVenue(venue.Name)
+		}
+	}
+	return out
+}
+
+func executeScan(ctx context.Context, input InputData, fn func(context.Context, InputData, chan model.CustomerOffice, chan error)) (out OutputData, errs []error) {
+	out = newOutputData(input)
 	errs = make([]error, 0)
 	var ch = make(chan model.CustomerOffice, 1000)
 	var errCh = make(chan error, 1000)
-	defer func() {
-		_ = recover()
-		// destroy channels on exit
-		close(ch)
-		close(errCh)
-	}()
-	fn, err := createChannelWriterFunc(input.FileOrStream)
-	if err != nil {
-		errs = append(errs, err)
-		return out, errs
-	}
-	go fn(input, ch, errCh)
-	var running = true
+	// The reader closes the customers channel at the end of the stream
+	go fn(ctx, input, ch, errCh)
+	var collected = make(chan struct{})
 	go func(errChannel chan error) {
-		// Collecting errors
-		for running {
-			select {
-			case errX := <-errChannel:
-				errs = append(errs, errX)
-			case <-time.After(2 * time.Second):
-				continue
-			}
+		// Collecting errors, the only writer of the errors list until the scan ends
+		for errX := range errChannel {
+			errs = append(errs, errX)
 		}
+		close(collected)
 	}(errCh)
-	// Collecting customers
-computeCycle:
-	for true {
-		select {
-		case customer := <-ch:
-			go func(inputData InputData, customerOffice model.CustomerOffice, out *OutputData) {
-				// Verifies if customer has correct coordinates
-				if !customerOffice.IsValid() {
-					errs = append(errs, errors.New(fmt.Sprintf("Invalid coordinates data for customer [%v] %s", customer.UserId, customer.Name)))
-				}
-				// Recovers customer office latitude and longitude
-				lat, _ := customerOffice.GetLatitude()
-				long, _ := customerOffice.GetLongitude()
-				// Calculates distance
-				dist := geo.Distance(inputData.HomeLatitude, inputData.HomeLongitude, lat, long, inputData.MeasureUnit)
-				if out.IsComplete {
-					// If is detailed output collects invited and excluded  customers
-					if dist <= inputData.Distance {
-						out.Complete.AddInvited(model.ToInviteData(&customerOffice))
-					} else {
-						out.Complete.AddExcluded(model.ToInviteData(&customerOffice))
-					}
-				} else {
-					// If is simple output collects only invited customers
-					if dist <= inputData.Distance {
-						out.Simple.Add(model.ToInviteData(&customerOffice))
-					}
+	var sink = input.Sink
+	if sink == nil {
+		sink = &outputSink{&out}
+	}
+	var dedup = &deduplicator{policy: input.DuplicatePolicy, sink: sink, errCh: errCh}
+	var customers = dedup.run(ctx, ch)
+	// Collecting customers with a bounded pool of evaluation workers
+	var poolSize = input.WorkerPoolSize
+	if poolSize <= 0 {
+		poolSize = runtime.NumCPU()
+	}
+	var workers sync.WaitGroup
+	for i := 0; i < poolSize; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for customer := range customers {
+				if ctx.Err() != nil {
+					// Scan cancelled, draining the customers already read
+					continue
 				}
-			}(input, customer, &out)
-		case <-time.After(10 * time.Second):
-			running = false
-			break computeCycle
-		}
+				evaluateCustomer(input, customer, sink, errCh)
+			}
+		}()
+	}
+	// Waiting for the pending evaluations and the errors collection
+	workers.Wait()
+	close(errCh)
+	<-collected
+	out.Duplicates = dedup.duplicates
+	out.DroppedDuplicates = dedup.dropped
+	out.Simple.Sort(input.SortOrder)
+	out.Complete.Sort(input.SortOrder)
+	if ctx.Err() != nil {
+		errs = append(errs, ctx.Err())
 	}
 	out.IsDone = len(errs) == 0
 	return out, errs
 }
+
+func evaluateCustomer(inputData InputData, customerOffice model.CustomerOffice, sink ResultSink, errCh chan error) {
+	// Verifies if customer has correct coordinates
+	if errV := customerOffice.Validate(); errV != nil {
+		err := &model.CoordinateError{
+			UserId:    customerOffice.UserId,
+			Name:      customerOffice.Name,
+			Latitude:  customerOffice.Latitude,
+			Longitude: customerOffice.Longitude,
+			Err:       errV,
+		}
+		errCh <- err
+		sink.Rejected(customerOffice, err)
+		return
+	}
+	// Recovers customer office latitude and longitude
+	lat, _ := customerOffice.GetLatitude()
+	long, _ := customerOffice.GetLongitude()
+	// Calculates distance and verifies the invitation criteria
+	var dist float64
+	var invited bool
+	var venue string
+	if len(inputData.Venues) > 0 {
+		venue, dist, invited = nearestVenue(inputData, lat, long)
+	} else {
+		dist = inputData.Algorithm.Distance(inputData.HomeLatitude, inputData.HomeLongitude, lat, long, inputData.MeasureUnit)
+		if inputData.Geofence != nil {
+			invited = inputData.Geofence.Contains(lat, long)
+		} else {
+			invited = dist <= inputData.Distance
+		}
+	}
+	var details *model.CustomerDetails
+	if inputData.UseDetailedOutput {
+		// Detailed output reports the computed location
+		details = model.ToDetailedInviteData(&customerOffice, model.CustomerLocation{
+			Latitude:  lat,
+			Longitude: long,
+			Distance:  dist,
+			Unit:      string(inputData.MeasureUnit),
+		})
+	} else {
+		details = model.ToDistanceInviteData(&customerOffice, dist)
+	}
+	if invited {
+		details.Venue = venue
+		sink.Invited(*details)
+	} else {
+		sink.Excluded(*details)
+	}
+}
+
+// Finds the nearest venue having the customer within its radius, or the nearest venue and a
+// negative outcome when the customer is outside all the venues radius
+func nearestVenue(inputData InputData, lat float64, long float64) (venue string, dist float64, invited bool) {
+	dist = math.Inf(1)
+	for _, v := range inputData.Venues {
+		d := inputData.Algorithm.Distance(v.Latitude, v.Longitude, lat, long, inputData.MeasureUnit)
+		within := d <= v.Radius
+		if (within && !invited) || (within == invited && d < dist) {
+			venue, dist, invited = v.Name, d, within
+		}
+	}
+	return venue, dist, invited
+}
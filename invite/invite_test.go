@@ -9,10 +9,18 @@
 package invite
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hellgate75/go-invite-customers/geo"
 	io2 "github.com/hellgate75/go-invite-customers/io"
 	"github.com/hellgate75/go-invite-customers/model"
 	"io"
+	"net"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestExecuteInviteScan(t *testing.T) {
@@ -106,10 +114,139 @@ func TestExecuteInviteScan(t *testing.T) {
 			},
 			wantErrs: make([]error, 0),
 		},
+		{
+			name: "Test file case with detailed output",
+			args: args{
+				input: InputData{
+					UseDetailedOutput: true,
+					Distance:          100,
+					MeasureUnit:       "K",
+					HomeLongitude:     -6.257664,
+					HomeLatitude:      53.339428,
+					InputEncoding:     io2.JsonEncoding,
+					OutputEncoding:    io2.JsonEncoding,
+					SilentOutput:      true,
+					FileOrStream:      name,
+					UsePerLineInput:   true,
+					WorkerPoolSize:    2,
+				},
+			},
+			wantOut: OutputData{
+				IsDone:     true,
+				IsComplete: true,
+				Complete: &model.CompleteInviteList{
+					MatchingCustomerIds: []model.CustomerDetails{
+						{UserId: 12, Name: "Thomas Barret"},
+					},
+					UnMatchingCustomerIds: []model.CustomerDetails{
+						{UserId: 1, Name: "Michael Barret"},
+					},
+				},
+				Simple: model.NewInviteList(),
+			},
+			wantErrs: make([]error, 0),
+		},
+		{
+			name: "Test file case with geofence",
+			args: args{
+				input: InputData{
+					UseDetailedOutput: false,
+					Distance:          0.01,
+					MeasureUnit:       "K",
+					HomeLongitude:     -6.257664,
+					HomeLatitude:      53.339428,
+					InputEncoding:     io2.JsonEncoding,
+					OutputEncoding:    io2.JsonEncoding,
+					SilentOutput:      true,
+					FileOrStream:      name,
+					UsePerLineInput:   true,
+					Geofence: &geo.Geofence{Polygons: []geo.Polygon{{Rings: [][]geo.Point{{
+						{Latitude: 50, Longitude: -4}, {Latitude: 50, Longitude: -3},
+						{Latitude: 51, Longitude: -3}, {Latitude: 51, Longitude: -4},
+						{Latitude: 50, Longitude: -4},
+					}}}}},
+				},
+			},
+			wantOut: OutputData{
+				IsDone:     true,
+				IsComplete: false,
+				Complete:   model.NewCompleteInviteList(),
+				Simple: &model.InviteList{
+					CustomerIds: []model.CustomerDetails{
+						{UserId: 1, Name: "Michael Barret"},
+					},
+				},
+			},
+			wantErrs: make([]error, 0),
+		},
+		{
+			name: "Test file case with venues",
+			args: args{
+				input: InputData{
+					UseDetailedOutput: true,
+					Distance:          0.01,
+					MeasureUnit:       "K",
+					HomeLongitude:     -6.257664,
+					HomeLatitude:      53.339428,
+					InputEncoding:     io2.JsonEncoding,
+					OutputEncoding:    io2.JsonEncoding,
+					SilentOutput:      true,
+					FileOrStream:      name,
+					UsePerLineInput:   true,
+					Venues: []model.Venue{
+						{Name: "Dublin", Latitude: 53.339428, Longitude: -6.257664, Radius: 50},
+						{Name: "Exeter", Latitude: 50.72, Longitude: -3.53, Radius: 80},
+						{Name: "Cork", Latitude: 51.903614, Longitude: -8.468399, Radius: 40},
+					},
+				},
+			},
+			wantOut: OutputData{
+				IsDone:     true,
+				IsComplete: true,
+				Complete: &model.CompleteInviteList{
+					MatchingCustomerIds:   []model.CustomerDetails{},
+					UnMatchingCustomerIds: []model.CustomerDetails{},
+					Venues: []model.VenueInviteList{
+						{Venue: "Dublin", CustomerIds: []model.CustomerDetails{{UserId: 12, Name: "Thomas Barret"}}},
+						{Venue: "Exeter", CustomerIds: []model.CustomerDetails{{UserId: 1, Name: "Michael Barret"}}},
+						{Venue: "Cork", CustomerIds: []model.CustomerDetails{}},
+					},
+				},
+				Simple: model.NewInviteList(),
+			},
+			wantErrs: make([]error, 0),
+		},
+		{
+			name: "Test invalid measure unit case",
+			args: args{
+				input: InputData{
+					UseDetailedOutput: false,
+					Distance:          100,
+					MeasureUnit:       "k",
+					HomeLongitude:     -6.257664,
+					HomeLatitude:      53.339428,
+					InputEncoding:     io2.JsonEncoding,
+					OutputEncoding:    io2.JsonEncoding,
+					SilentOutput:      true,
+					FileOrStream:      name,
+					UsePerLineInput:   true,
+				},
+			},
+			wantOut: OutputData{
+				IsDone:     false,
+				IsComplete: false,
+				Complete:   model.NewCompleteInviteList(),
+				Simple:     model.NewInviteList(),
+			},
+			wantErrs: []error{errors.New("Invalid distance unit: 'k'")},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gotOut, gotErrs := ExecuteInviteScan(tt.args.input)
+			if gotOut.IsDone != tt.wantOut.IsDone {
+				t.Errorf("ExecuteInviteScan() gotOut IsDone = %v, want %v", gotOut.IsDone, tt.wantOut.IsDone)
+			}
 			if len(gotErrs) != len(tt.wantErrs) {
 				t.Errorf("ExecuteInviteScan() gotErrs = %v, want %v", gotErrs, tt.wantErrs)
 			}
@@ -122,6 +259,162 @@ func TestExecuteInviteScan(t *testing.T) {
 			if len(gotOut.Complete.UnMatchingCustomerIds) != len(tt.wantOut.Complete.UnMatchingCustomerIds) {
 				t.Errorf("ExecuteInviteScan() gotOut Complete.UnMatchingCustomerIds = %+v, want %+v", gotOut.Complete.UnMatchingCustomerIds, tt.wantOut.Complete.UnMatchingCustomerIds)
 			}
+			if len(gotOut.Complete.Venues) != len(tt.wantOut.Complete.Venues) {
+				t.Errorf("ExecuteInviteScan() gotOut Complete.Venues = %+v, want %+v", gotOut.Complete.Venues, tt.wantOut.Complete.Venues)
+			}
+			for i := 0; i < len(gotOut.Complete.Venues) && i < len(tt.wantOut.Complete.Venues); i++ {
+				got, want := gotOut.Complete.Venues[i], tt.wantOut.Complete.Venues[i]
+				if got.Venue != want.Venue || len(got.CustomerIds) != len(want.CustomerIds) {
+					t.Errorf("ExecuteInviteScan() gotOut Complete.Venues[%v] = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteInviteScanWithContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Errorf("net.Listen() error = %v, opening test tcp server", err)
+		return
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		// Sends a single customer and keeps the connection open
+		_, _ = conn.Write([]byte("{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}\n"))
+	}()
+	input := InputData{
+		UseDetailedOutput: false,
+		Distance:          100,
+		MeasureUnit:       "K",
+		HomeLongitude:     -6.257664,
+		HomeLatitude:      53.339428,
+		InputEncoding:     io2.JsonEncoding,
+		OutputEncoding:    io2.JsonEncoding,
+		SilentOutput:      true,
+		FileOrStream:      "tcp://" + listener.Addr().String(),
+		UsePerLineInput:   true,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	gotOut, gotErrs := ExecuteInviteScanWithContext(ctx, input)
+	if gotOut.IsDone {
+		t.Errorf("ExecuteInviteScanWithContext() gotOut IsDone = %v, want %v", gotOut.IsDone, false)
+	}
+	if len(gotErrs) != 1 || gotErrs[0] != context.DeadlineExceeded {
+		t.Errorf("ExecuteInviteScanWithContext() gotErrs = %v, want %v", gotErrs, []error{context.DeadlineExceeded})
+	}
+	if len(gotOut.Simple.CustomerIds) != 1 {
+		t.Errorf("ExecuteInviteScanWithContext() gotOut Simple.CustomerIds = %+v, want %v customers", gotOut.Simple.CustomerIds, 1)
+	}
+}
+
+func TestExecuteInviteScanFromReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		perLine     bool
+		wantInvited int
+		wantErrs    int
+	}{
+		{
+			name:        "Test per line reader",
+			data:        "{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}\n{bad}\n",
+			perLine:     true,
+			wantInvited: 1,
+			wantErrs:    1,
+		},
+		{
+			name:        "Test list reader",
+			data:        "{\"customers\": [{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}]}",
+			perLine:     false,
+			wantInvited: 1,
+			wantErrs:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, errs := ExecuteInviteScanFromReader(context.Background(), strings.NewReader(tt.data), InputData{
+				Distance:        100,
+				MeasureUnit:     "K",
+				HomeLongitude:   -6.257664,
+				HomeLatitude:    53.339428,
+				InputEncoding:   io2.JsonEncoding,
+				UsePerLineInput: tt.perLine,
+			})
+			if len(errs) != tt.wantErrs {
+				t.Errorf("ExecuteInviteScanFromReader() errs = %v, want %v errors", errs, tt.wantErrs)
+			}
+			if len(out.Simple.CustomerIds) != tt.wantInvited {
+				t.Errorf("ExecuteInviteScanFromReader() invited = %+v, want %v customers", out.Simple.CustomerIds, tt.wantInvited)
+			}
+		})
+	}
+}
+
+func TestExecuteInviteScanFromChannel(t *testing.T) {
+	tests := []struct {
+		name        string
+		customers   []model.CustomerOffice
+		cancel      bool
+		wantInvited int
+		wantErrs    int
+	}{
+		{
+			name: "Test channel customers",
+			customers: []model.CustomerOffice{
+				{UserId: 12, Name: "Thomas Barret", Latitude: "53.339111", Longitude: "-6.257611"},
+				{UserId: 1, Name: "Michael Barret", Latitude: "51.903614", Longitude: "-8.468399"},
+				{UserId: 2, Name: "Mark Barret", Latitude: "95", Longitude: "-8.468399"},
+			},
+			wantInvited: 1,
+			wantErrs:    1,
+		},
+		{
+			name:        "Test empty channel",
+			customers:   []model.CustomerOffice{},
+			wantInvited: 0,
+			wantErrs:    0,
+		},
+		{
+			name:        "Test cancelled scan with open channel",
+			customers:   []model.CustomerOffice{},
+			cancel:      true,
+			wantInvited: 0,
+			wantErrs:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ch := make(chan model.CustomerOffice, len(tt.customers))
+			for _, customer := range tt.customers {
+				ch <- customer
+			}
+			if tt.cancel {
+				cancel()
+			} else {
+				close(ch)
+			}
+			out, errs := ExecuteInviteScanFromChannel(ctx, ch, InputData{
+				Distance:      100,
+				MeasureUnit:   "K",
+				HomeLongitude: -6.257664,
+				HomeLatitude:  53.339428,
+			})
+			if len(errs) != tt.wantErrs {
+				t.Errorf("ExecuteInviteScanFromChannel() errs = %v, want %v errors", errs, tt.wantErrs)
+			}
+			if len(out.Simple.CustomerIds) != tt.wantInvited {
+				t.Errorf("ExecuteInviteScanFromChannel() invited = %+v, want %v customers", out.Simple.CustomerIds, tt.wantInvited)
+			}
 		})
 	}
 }
@@ -181,7 +474,7 @@ func Test_createChannelWriterFunc(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotFunction, err := createChannelWriterFunc(tt.args.url)
+			gotFunction, err := createChannelWriterFunc(tt.args.url, InputData{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createChannelWriterFunc() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -243,7 +536,7 @@ func Test_parseAndServerList(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			parseAndServerList(tt.args.r, tt.args.inputData, tt.args.ch, tt.args.errCh)
+			parseAndServerList(context.Background(), tt.args.r, tt.args.inputData, tt.args.ch, tt.args.errCh)
 		})
 	}
 }
@@ -298,7 +591,234 @@ func Test_readLineByLine(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			readLineByLine(tt.args.r, tt.args.inputData, tt.args.ch, tt.args.errCh)
+			readLineByLine(context.Background(), tt.args.r, tt.args.inputData, tt.args.ch, tt.args.errCh)
+		})
+	}
+}
+
+func Test_readers_csvInput(t *testing.T) {
+	data := "id;ignored;full name;lat;lng\n" +
+		"12;x;Thomas Barret;53.339111;-6.257611\n" +
+		"\n" +
+		"ab;x;Michael Barret;50.339428;-3.257664\n" +
+		"1;x;Michael Barret\n" +
+		"2;x;\"Barret, John\";50.339428;-3.257664\n"
+	mapping := io2.CsvMapping{UserId: "id", Name: "full name", Latitude: "lat", Longitude: "lng"}
+	tests := []struct {
+		name          string
+		perLine       bool
+		enc           io2.Encoding
+		data          string
+		wantCustomers []int64
+		wantErrs      int
+	}{
+		{
+			name:          "Test reading csv line by line",
+			perLine:       true,
+			enc:           io2.CsvEncoding,
+			data:          strings.Replace(data, ";", ",", -1),
+			wantCustomers: []int64{12, 2},
+			wantErrs:      2,
+		},
+		{
+			name:          "Test reading csv document",
+			perLine:       false,
+			enc:           io2.CsvEncoding,
+			data:          strings.Replace(data, ";", ",", -1),
+			wantCustomers: []int64{12, 2},
+			wantErrs:      2,
+		},
+		{
+			name:          "Test reading tsv document",
+			perLine:       false,
+			enc:           io2.TsvEncoding,
+			data:          strings.Replace(data, ";", "\t", -1),
+			wantCustomers: []int64{12, 2},
+			wantErrs:      2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan model.CustomerOffice, 1000)
+			errCh := make(chan error, 1000)
+			inputData := InputData{InputEncoding: tt.enc, UsePerLineInput: tt.perLine, CsvMapping: mapping}
+			if tt.perLine {
+				readLineByLine(context.Background(), strings.NewReader(tt.data), inputData, ch, errCh)
+			} else {
+				parseAndServerList(context.Background(), strings.NewReader(tt.data), inputData, ch, errCh)
+			}
+			close(ch)
+			close(errCh)
+			gotCustomers := make([]int64, 0)
+			for customer := range ch {
+				gotCustomers = append(gotCustomers, customer.UserId)
+			}
+			if !reflect.DeepEqual(gotCustomers, tt.wantCustomers) {
+				t.Errorf("reader customers = %v, want %v", gotCustomers, tt.wantCustomers)
+			}
+			gotErrs := make([]error, 0)
+			for err := range errCh {
+				gotErrs = append(gotErrs, err)
+			}
+			if len(gotErrs) != tt.wantErrs {
+				t.Errorf("reader errors = %v, want %v errors", gotErrs, tt.wantErrs)
+			}
+			for _, err := range gotErrs {
+				if !strings.HasPrefix(err.Error(), "Line 4:") && !strings.HasPrefix(err.Error(), "Line 5:") {
+					t.Errorf("reader error = %v, want line number 4 or 5", err)
+				}
+			}
 		})
 	}
 }
+
+func Test_readLineByLine_parseErrors(t *testing.T) {
+	data := "{\"user_id\": 1, \"name\": \"Thomas Barret\", \"latitude\": \"53.3\", \"longitude\": \"-6.2\"}\n" +
+		"{bad\n" +
+		"{\"user_id\": \"two\"}\n"
+	ch := make(chan model.CustomerOffice, 10)
+	errCh := make(chan error, 10)
+	readLineByLine(context.Background(), strings.NewReader(data), InputData{InputEncoding: io2.JsonEncoding}, ch, errCh)
+	close(ch)
+	close(errCh)
+	if len(ch) != 1 {
+		t.Errorf("readLineByLine() customers = %v, want %v", len(ch), 1)
+	}
+	want := []model.ParseError{
+		{Line: 2, Offset: 81, Snippet: "{bad"},
+		{Line: 3, Offset: 86, Snippet: "{\"user_id\": \"two\"}"},
+	}
+	i := 0
+	for err := range errCh {
+		var parseError *model.ParseError
+		if !errors.As(err, &parseError) {
+			t.Errorf("readLineByLine() error = %v, want model.ParseError", err)
+			continue
+		}
+		if i < len(want) && (parseError.Line != want[i].Line || parseError.Offset != want[i].Offset || parseError.Snippet != want[i].Snippet) {
+			t.Errorf("readLineByLine() error = %+v, want %+v", parseError, want[i])
+		}
+		i++
+	}
+	if i != len(want) {
+		t.Errorf("readLineByLine() errors = %v, want %v", i, len(want))
+	}
+}
+
+func Test_newDocumentParseError(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		enc         io2.Encoding
+		wantLine    int
+		wantOffset  int64
+		wantSnippet string
+	}{
+		{
+			name:        "Locate json syntax error",
+			data:        "{\"customers\":[\n{\"user_id\":1},\n{bad}]}\n",
+			enc:         io2.JsonEncoding,
+			wantLine:    3,
+			wantOffset:  30,
+			wantSnippet: "{bad}]}",
+		},
+		{
+			name:        "Locate json type error",
+			data:        "{\"customers\":\n\"none\"}\n",
+			enc:         io2.JsonEncoding,
+			wantLine:    2,
+			wantOffset:  14,
+			wantSnippet: "\"none\"}",
+		},
+		{
+			name:        "Locate json record error",
+			data:        "{\"customers\":[\n{\"user_id\":\"one\"}]}\n",
+			enc:         io2.JsonEncoding,
+			wantLine:    2,
+			wantOffset:  15,
+			wantSnippet: "{\"user_id\":\"one\"}]}",
+		},
+		{
+			name:        "Locate json record type error",
+			data:        "{\"customers\":[\n{\"user_id\":1},\n  {\"user_id\":2,\n\"name\":3}]}\n",
+			enc:         io2.JsonEncoding,
+			wantLine:    4,
+			wantOffset:  46,
+			wantSnippet: "\"name\":3}]}",
+		},
+		{
+			name:        "Locate yaml error",
+			data:        "customers:\n- user_id: 1\n  name: [a\n",
+			enc:         io2.YamlEncoding,
+			wantLine:    3,
+			wantOffset:  24,
+			wantSnippet: "  name: [a",
+		},
+		{
+			name:        "Locate xml error",
+			data:        "<CustomerOfficeList>\n<customers><user-id>1</user-id>\n</CustomerOfficeList>\n",
+			enc:         io2.XmlEncoding,
+			wantLine:    3,
+			wantOffset:  53,
+			wantSnippet: "</CustomerOfficeList>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := io2.ReadCustomerOfficeList([]byte(tt.data), tt.enc)
+			if err == nil {
+				t.Errorf("ReadCustomerOfficeList() error = nil, want error")
+				return
+			}
+			got := newDocumentParseError([]byte(tt.data), err)
+			if got.Line != tt.wantLine || got.Offset != tt.wantOffset || got.Snippet != tt.wantSnippet {
+				t.Errorf("newDocumentParseError() = %+v, want line %v, offset %v and snippet %q", got, tt.wantLine, tt.wantOffset, tt.wantSnippet)
+			}
+			if !errors.Is(got, err) {
+				t.Errorf("newDocumentParseError() does not wrap %v", err)
+			}
+		})
+	}
+}
+
+func BenchmarkExecuteInviteScan(b *testing.B) {
+	const customers = 1000000
+	input := InputData{
+		UseDetailedOutput: true,
+		Distance:          100,
+		MeasureUnit:       "K",
+		HomeLongitude:     -6.257664,
+		HomeLatitude:      53.339428,
+		InputEncoding:     io2.JsonEncoding,
+		OutputEncoding:    io2.JsonEncoding,
+		SilentOutput:      true,
+		UsePerLineInput:   true,
+	}
+	generator := func(ctx context.Context, inputData InputData, ch chan model.CustomerOffice, errCh chan error) {
+		defer close(ch)
+		for i := 0; i < customers; i++ {
+			customer := model.CustomerOffice{
+				UserId:    int64(i),
+				Name:      "Thomas Barret",
+				Latitude:  fmt.Sprintf("%f", 51.0+float64(i%400)/100),
+				Longitude: fmt.Sprintf("%f", -8.0+float64(i%300)/100),
+			}
+			if !sendCustomer(ctx, customer, ch) {
+				return
+			}
+		}
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		start := time.Now()
+		out, errs := executeScan(context.Background(), input, generator)
+		elapsed := time.Since(start)
+		if len(errs) > 0 {
+			b.Fatalf("executeScan() errs = %v", errs)
+		}
+		if got := len(out.Complete.MatchingCustomerIds) + len(out.Complete.UnMatchingCustomerIds); got != customers {
+			b.Fatalf("executeScan() evaluated customers = %v, want %v", got, customers)
+		}
+		b.ReportMetric(float64(customers)/elapsed.Seconds(), "customers/s")
+	}
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stream of the records pushed to a listening tcp server, merged line by line
+type listenStream struct {
+	m sync.Mutex
+	// Serializes the lines written by the connections, so they are never interleaved
+	wm          sync.Mutex
+	reader      *io.PipeReader
+	writer      *io.PipeWriter
+	closer      io.Closer
+	conns       map[net.Conn]bool
+	idleTimeout time.Duration
+	timer       *time.Timer
+	termination string
+	done        bool
+}
+
+//  Listen for tcp connections at the address of given url, reading the records pushed by any
+//  number of clients, one record per line.
+//
+//  TcpUrl/
+//  Listen url (tcp-listen://host:port => tcp-listen://:19099 or tcp-listen://0.0.0.0:19099)
+//
+//  IdleTimeout/
+//  Time without any connection or record after which the stream ends, zero for no timeout
+//
+//  Termination/
+//  Line that ends the stream when received from any client, empty for no termination message
+//
+//  The output are the stream reader (to close) and the error, if any error occurs during the
+//  listener opening operation. The reader returns the received lines and io.EOF at the end of
+//  the stream, when closed the listener and the client connections are closed.
+func ListenTcpStream(tcpUrl string, idleTimeout time.Duration, termination string) (io.ReadCloser, error) {
+	if tcpUrl == "" {
+		return nil, errors.New(fmt.Sprint("Empty tcp listen url"))
+	}
+	if !strings.HasPrefix(tcpUrl, "tcp-listen://") || len(tcpUrl) == len("tcp-listen://") {
+		return nil, errors.New(fmt.Sprintf("Invalid tcp listen url: %s", tcpUrl))
+	}
+	listener, err := net.Listen("tcp", tcpUrl[len("tcp-listen://"):])
+	if err != nil {
+		return nil, err
+	}
+	s := newListenStream(listener, idleTimeout, termination)
+	go s.acceptConnections(listener)
+	return s, nil
+}
+
+//  Listen for udp datagrams at the address of given url, pushed by any number of clients.
+//
+//  UdpUrl/
+//  Listen url (udp-listen://host:port => udp-listen://:19099 or udp-listen://0.0.0.0:19099)
+//
+//  The output are the packet connection (to close) and the error, if any error occurs during the
+//  listener opening operation.
+func ListenUdpStream(udpUrl string) (net.PacketConn, error) {
+	if udpUrl == "" {
+		return nil, errors.New(fmt.Sprint("Empty udp listen url"))
+	}
+	if !strings.HasPrefix(udpUrl, "udp-listen://") || len(udpUrl) == len("udp-listen://") {
+		return nil, errors.New(fmt.Sprintf("Invalid udp listen url: %s", udpUrl))
+	}
+	return net.ListenPacket("udp", udpUrl[len("udp-listen://"):])
+}
+
+func newListenStream(closer io.Closer, idleTimeout time.Duration, termination string) *listenStream {
+	reader, writer := io.Pipe()
+	s := &listenStream{
+		reader:      reader,
+		writer:      writer,
+		closer:      closer,
+		conns:       make(map[net.Conn]bool),
+		idleTimeout: idleTimeout,
+		termination: strings.TrimSpace(termination),
+	}
+	if idleTimeout > 0 {
+		s.timer = time.AfterFunc(idleTimeout, func() {
+			s.finish(nil)
+		})
+	}
+	return s
+}
+
+func (s *listenStream) Read(p []byte) (int, error) {
+	return s.reader.Read(p)
+}
+
+func (s *listenStream) Close() error {
+	// Unblocking any pending line write, before ending the stream
+	err := s.reader.Close()
+	s.finish(nil)
+	return err
+}
+
+// End the stream closing the listener and the connections, the reader gets the error or io.EOF
+func (s *listenStream) finish(err error) {
+	s.m.Lock()
+	if s.done {
+		s.m.Unlock()
+		return
+	}
+	s.done = true
+	conns := s.conns
+	s.conns = make(map[net.Conn]bool)
+	s.m.Unlock()
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	_ = s.closer.Close()
+	for conn := range conns {
+		_ = conn.Close()
+	}
+	_ = s.writer.CloseWithError(err)
+}
+
+// Track the connection, to be closed at the end of the stream, false when the stream is ended
+func (s *listenStream) track(conn net.Conn) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.done {
+		return false
+	}
+	s.conns[conn] = true
+	s.resetTimer()
+	return true
+}
+
+func (s *listenStream) untrack(conn net.Conn) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	delete(s.conns, conn)
+}
+
+// Restart the idle timeout, called with the stream lock held
+func (s *listenStream) resetTimer() {
+	if s.timer != nil && !s.done {
+		s.timer.Reset(s.idleTimeout)
+	}
+}
+
+// Write the received line to the stream, false when the stream is ended
+func (s *listenStream) writeLine(line []byte) bool {
+	s.wm.Lock()
+	defer s.wm.Unlock()
+	s.m.Lock()
+	done := s.done
+	s.resetTimer()
+	s.m.Unlock()
+	if done {
+		return false
+	}
+	if s.termination != "" && string(bytes.TrimSpace(line)) == s.termination {
+		s.finish(nil)
+		return false
+	}
+	record := make([]byte, 0, len(line)+1)
+	record = append(append(record, line...), '\n')
+	_, err := s.writer.Write(record)
+	return err == nil
+}
+
+func (s *listenStream) acceptConnections(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// Listener closed at the end of the stream, or failed
+			s.finish(err)
+			return
+		}
+		if !s.track(conn) {
+			_ = conn.Close()
+			return
+		}
+		go s.readConnection(conn)
+	}
+}
+
+func (s *listenStream) readConnection(conn net.Conn) {
+	defer func() {
+		s.untrack(conn)
+		_ = conn.Close()
+	}()
+	br := bufio.NewReader(conn)
+	for {
+		line, err := br.ReadBytes('\n')
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) > 0 || err == nil {
+			if !s.writeLine(line) {
+				return
+			}
+		}
+		if err != nil {
+			// The client closed the connection, the stream waits for the other ones
+			return
+		}
+	}
+}
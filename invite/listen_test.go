@@ -0,0 +1,274 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"context"
+	"fmt"
+	io2 "github.com/hellgate75/go-invite-customers/io"
+	"github.com/hellgate75/go-invite-customers/model"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Get a free local port of the network, for the listen urls
+func freePort(t *testing.T, network string) int {
+	if network == "udp" {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("ListenPacket() error = %v", err)
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		return conn.LocalAddr().(*net.UDPAddr).Port
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// Sorted lines of the stream data, clients writes can arrive in any order
+func sortedLines(data []byte) []string {
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	sort.Strings(lines)
+	return lines
+}
+
+func TestListenTcpStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		idleTimeout time.Duration
+		termination string
+		clients     []string
+		wantLines   []string
+		wantErr     bool
+	}{
+		{
+			name:        "Test clients ended by termination message",
+			termination: "END",
+			clients:     []string{"a1\na2\n", "b1\r\nEND\nb2\n"},
+			wantLines:   []string{"a1", "a2", "b1"},
+		},
+		{
+			name:        "Test clients ended by idle timeout",
+			idleTimeout: 300 * time.Millisecond,
+			clients:     []string{"a1\n", "b1"},
+			wantLines:   []string{"a1", "b1"},
+		},
+		{
+			name:    "Test empty tcp listen url",
+			url:     "",
+			wantErr: true,
+		},
+		{
+			name:    "Test invalid tcp listen url",
+			url:     "tcp://127.0.0.1:19099",
+			wantErr: true,
+		},
+		{
+			name:    "Test missing tcp listen address",
+			url:     "tcp-listen://",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := tt.url
+			if !tt.wantErr {
+				url = fmt.Sprintf("tcp-listen://127.0.0.1:%v", freePort(t, "tcp"))
+			}
+			stream, err := ListenTcpStream(url, tt.idleTimeout, tt.termination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListenTcpStream() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			defer func() {
+				_ = stream.Close()
+			}()
+			// The termination message is sent by the last client, after the other ones completed
+			for _, client := range tt.clients {
+				conn, errD := net.Dial("tcp", url[len("tcp-listen://"):])
+				if errD != nil {
+					t.Errorf("Dial() error = %v", errD)
+					return
+				}
+				_, _ = conn.Write([]byte(client))
+				time.Sleep(50 * time.Millisecond)
+				_ = conn.Close()
+			}
+			data, err := ioutil.ReadAll(stream)
+			if err != nil {
+				t.Errorf("ListenTcpStream() read error = %v", err)
+			}
+			if got := sortedLines(data); strings.Join(got, ",") != strings.Join(tt.wantLines, ",") {
+				t.Errorf("ListenTcpStream() lines = %v, want %v", got, tt.wantLines)
+			}
+		})
+	}
+}
+
+// Json record of the customer with the given user id, for the udp listen datagrams
+func listenRecord(userId int) string {
+	return fmt.Sprintf("{\"latitude\": \"53.339111\", \"user_id\": %v, \"name\": \"Customer %v\", \"longitude\": \"-6.257611\"}", userId, userId)
+}
+
+func TestListenUdpStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		idleTimeout time.Duration
+		endMessage  string
+		datagrams   []string
+		wantUsers   []string
+		wantErr     bool
+	}{
+		{
+			name:       "Test datagrams ended by end message",
+			endMessage: "END",
+			datagrams:  []string{listenRecord(1) + "\n" + listenRecord(2) + "\n", "", listenRecord(3) + "\r\n", "END", listenRecord(4)},
+			wantUsers:  []string{"1", "2", "3"},
+		},
+		{
+			name:        "Test datagrams ended by idle timeout",
+			idleTimeout: 300 * time.Millisecond,
+			datagrams:   []string{listenRecord(1), listenRecord(2) + "\n"},
+			wantUsers:   []string{"1", "2"},
+		},
+		{
+			name:    "Test empty udp listen url",
+			url:     "",
+			wantErr: true,
+		},
+		{
+			name:    "Test invalid udp listen url",
+			url:     "udp://127.0.0.1:19099",
+			wantErr: true,
+		},
+		{
+			name:    "Test missing udp listen address",
+			url:     "udp-listen://",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := tt.url
+			if !tt.wantErr {
+				url = fmt.Sprintf("udp-listen://127.0.0.1:%v", freePort(t, "udp"))
+			}
+			listener, err := ListenUdpStream(url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListenUdpStream() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			defer func() {
+				_ = listener.Close()
+			}()
+			conn, err := net.Dial("udp", url[len("udp-listen://"):])
+			if err != nil {
+				t.Errorf("Dial() error = %v", err)
+				return
+			}
+			defer func() {
+				_ = conn.Close()
+			}()
+			go func() {
+				for _, datagram := range tt.datagrams {
+					_, _ = conn.Write([]byte(datagram))
+					time.Sleep(20 * time.Millisecond)
+				}
+			}()
+			inputData := InputData{
+				FileOrStream:    url,
+				InputEncoding:   io2.JsonEncoding,
+				UsePerLineInput: true,
+				IdleTimeout:     tt.idleTimeout,
+				EndMessage:      tt.endMessage,
+			}
+			ch := make(chan model.CustomerOffice, 10)
+			errCh := make(chan error, 10)
+			readDatagrams(context.Background(), listener, inputData, ch, errCh)
+			close(ch)
+			close(errCh)
+			for err := range errCh {
+				t.Errorf("readDatagrams() error = %v", err)
+			}
+			users := make([]string, 0)
+			for customer := range ch {
+				users = append(users, fmt.Sprint(customer.UserId))
+			}
+			if strings.Join(users, ",") != strings.Join(tt.wantUsers, ",") {
+				t.Errorf("ListenUdpStream() users = %v, want %v", users, tt.wantUsers)
+			}
+		})
+	}
+}
+
+func TestExecuteInviteScan_listenInput(t *testing.T) {
+	for _, network := range []string{"tcp", "udp"} {
+		t.Run(fmt.Sprintf("Test %s-listen input", network), func(t *testing.T) {
+			address := fmt.Sprintf("127.0.0.1:%v", freePort(t, network))
+			go func() {
+				// Sending the records once the scan is listening
+				time.Sleep(200 * time.Millisecond)
+				conn, err := net.Dial(network, address)
+				if err != nil {
+					return
+				}
+				defer func() {
+					_ = conn.Close()
+				}()
+				lines := []string{
+					"{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}",
+					"{\"latitude\": \"51.903614\", \"user_id\": 1, \"name\": \"Michael Barret\", \"longitude\": \"-8.468399\"}",
+					"QUIT",
+				}
+				for _, line := range lines {
+					_, _ = conn.Write([]byte(line + "\n"))
+					time.Sleep(20 * time.Millisecond)
+				}
+			}()
+			out, errs := ExecuteInviteScan(InputData{
+				FileOrStream:      network + "-listen://" + address,
+				Distance:          100,
+				MeasureUnit:       "K",
+				HomeLongitude:     -6.257664,
+				HomeLatitude:      53.339428,
+				InputEncoding:     io2.JsonEncoding,
+				UsePerLineInput:   true,
+				UseDetailedOutput: true,
+				IdleTimeout:       5 * time.Second,
+				EndMessage:        "QUIT",
+			})
+			if len(errs) != 0 {
+				t.Errorf("ExecuteInviteScan() errs = %v, want none", errs)
+			}
+			if len(out.Complete.MatchingCustomerIds) != 1 || len(out.Complete.UnMatchingCustomerIds) != 1 {
+				t.Errorf("ExecuteInviteScan() out = %+v, want 1 invited and 1 excluded customers", out.Complete)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sftp file download, over the ssh connection
+type sftpStream struct {
+	conn   *ssh.Client
+	client *sftp.Client
+	file   *sftp.File
+}
+
+//  Open the file of the sftp server at given url, verifying the server key with the known hosts
+//  file (INVITE_SFTP_KNOWN_HOSTS environment variable, or $HOME/.ssh/known_hosts).
+//
+//  SftpUrl/
+//  Input url (sftp://[user[:password]@]host[:port]/path => sftp://files.example.com/data/customers.txt),
+//  the path is absolute, or relative to the user home if it starts with /~/. Without user the
+//  INVITE_SFTP_USER and INVITE_SFTP_PASSWORD environment variables are used, the private key of
+//  the INVITE_SFTP_KEY_FILE environment variable is used for the public key authentication
+//
+//  The output are the file reader (to close, completing the download) and the error, if any
+//  error occurs during the login or the file opening.
+func OpenSftpStream(sftpUrl string) (io.ReadCloser, error) {
+	if sftpUrl == "" {
+		return nil, errors.New(fmt.Sprint("Empty sftp url"))
+	}
+	u, err := parseRemoteUrl(sftpUrl, "sftp", "22")
+	if err != nil {
+		return nil, err
+	}
+	user, password := remoteCredentials(u, SftpUserEnv, SftpPasswordEnv)
+	if user == "" {
+		return nil, errors.New(fmt.Sprintf("Missing sftp user of url: %s, set in the url or the %s environment variable", redactedUrl(sftpUrl), SftpUserEnv))
+	}
+	config, err := sftpClientConfig(user, password)
+	if err != nil {
+		return nil, err
+	}
+	s := &sftpStream{}
+	if s.conn, err = ssh.Dial("tcp", u.Host, config); err != nil {
+		return nil, err
+	}
+	if s.client, err = sftp.NewClient(s.conn); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	path := u.Path
+	if strings.HasPrefix(path, "/~/") {
+		path = path[3:]
+	}
+	if s.file, err = s.client.Open(path); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Ssh configuration of the sftp login, with the password and the private key authentications
+func sftpClientConfig(user string, password string) (*ssh.ClientConfig, error) {
+	knownHostsFile := os.Getenv(SftpKnownHostsEnv)
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Unable to load the sftp known hosts file %s: %v", knownHostsFile, err))
+	}
+	auth := make([]ssh.AuthMethod, 0)
+	if keyFile := os.Getenv(SftpKeyFileEnv); keyFile != "" {
+		key, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid sftp private key file %s: %v", keyFile, err))
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if password != "" {
+		auth = append(auth, ssh.Password(password))
+	}
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         remoteDialTimeout,
+	}, nil
+}
+
+func (s *sftpStream) Read(p []byte) (int, error) {
+	return s.file.Read(p)
+}
+
+func (s *sftpStream) Close() error {
+	var err error
+	if s.file != nil {
+		err = s.file.Close()
+	}
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	if errC := s.conn.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
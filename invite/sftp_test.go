@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+)
+
+// Minimal sftp server of the tests, serving the files of the working directory to one user with
+// the password authentication. The output is the server address and its public host key.
+func startSftpServer(t *testing.T, user string, password string) (string, ssh.PublicKey) {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatalf("NewSignerFromKey() error = %v", err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if conn.User() == user && string(pass) == password {
+				return nil, nil
+			}
+			return nil, errors.New("password rejected")
+		},
+	}
+	config.AddHostKey(signer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSftp(conn, config)
+		}
+	}()
+	return listener.Addr().String(), signer.PublicKey()
+}
+
+func serveSftp(conn net.Conn, config *ssh.ServerConfig) {
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = serverConn.Close()
+	}()
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range channelRequests {
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+				if !ok {
+					continue
+				}
+				server, err := sftp.NewServer(channel)
+				if err != nil {
+					_ = channel.Close()
+					return
+				}
+				_ = server.Serve()
+				_ = server.Close()
+			}
+		}()
+	}
+}
+
+// Write the temporary file of the test, removed at the end of the test
+func writeTestFile(t *testing.T, content string) string {
+	file, err := ioutil.TempFile("", uuid.New().String())
+	if err != nil {
+		t.Fatalf("TempFile() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Remove(file.Name())
+	})
+	defer func() {
+		_ = file.Close()
+	}()
+	if _, err = file.WriteString(content); err != nil {
+		t.Fatalf("WriteString() error = %v", err)
+	}
+	return file.Name()
+}
+
+// Write the known hosts file of the server key
+func writeKnownHosts(t *testing.T, address string, key ssh.PublicKey) string {
+	return writeTestFile(t, knownhosts.Line([]string{knownhosts.Normalize(address)}, key)+"\n")
+}
+
+func TestOpenSftpStream(t *testing.T) {
+	fileName := writeTestFile(t, remoteCustomers)
+	address, hostKey := startSftpServer(t, "invite", "secret")
+	_, otherHostKey := startSftpServer(t, "invite", "secret")
+	knownHosts := writeKnownHosts(t, address, hostKey)
+	otherKnownHosts := writeKnownHosts(t, address, otherHostKey)
+	tests := []struct {
+		name    string
+		url     string
+		env     map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Test sftp url credentials",
+			url:  fmt.Sprintf("sftp://invite:secret@%s%s", address, fileName),
+			env:  map[string]string{SftpKnownHostsEnv: knownHosts},
+		},
+		{
+			name: "Test sftp environment credentials",
+			url:  fmt.Sprintf("sftp://%s%s", address, fileName),
+			env:  map[string]string{SftpKnownHostsEnv: knownHosts, SftpUserEnv: "invite", SftpPasswordEnv: "secret"},
+		},
+		{
+			name:    "Test sftp wrong password",
+			url:     fmt.Sprintf("sftp://invite:wrong@%s%s", address, fileName),
+			env:     map[string]string{SftpKnownHostsEnv: knownHosts},
+			wantErr: true,
+		},
+		{
+			name:    "Test sftp missing user",
+			url:     fmt.Sprintf("sftp://%s%s", address, fileName),
+			env:     map[string]string{SftpKnownHostsEnv: knownHosts},
+			wantErr: true,
+		},
+		{
+			name:    "Test sftp unknown host key",
+			url:     fmt.Sprintf("sftp://invite:secret@%s%s", address, fileName),
+			env:     map[string]string{SftpKnownHostsEnv: otherKnownHosts},
+			wantErr: true,
+		},
+		{
+			name:    "Test sftp missing known hosts file",
+			url:     fmt.Sprintf("sftp://invite:secret@%s%s", address, fileName),
+			env:     map[string]string{SftpKnownHostsEnv: fileName + ".known_hosts"},
+			wantErr: true,
+		},
+		{
+			name:    "Test sftp missing file",
+			url:     fmt.Sprintf("sftp://invite:secret@%s%s", address, fileName+".missing"),
+			env:     map[string]string{SftpKnownHostsEnv: knownHosts},
+			wantErr: true,
+		},
+		{
+			name:    "Test empty sftp url",
+			url:     "",
+			wantErr: true,
+		},
+		{
+			name:    "Test invalid sftp url scheme",
+			url:     fmt.Sprintf("ftp://invite:secret@%s%s", address, fileName),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestEnv(t, map[string]string{SftpUserEnv: "", SftpPasswordEnv: "", SftpKeyFileEnv: ""})
+			setTestEnv(t, tt.env)
+			stream, err := OpenSftpStream(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OpenSftpStream() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			data, err := ioutil.ReadAll(stream)
+			if err != nil {
+				t.Errorf("OpenSftpStream() read error = %v", err)
+			}
+			if err = stream.Close(); err != nil {
+				t.Errorf("OpenSftpStream() Close() error = %v", err)
+			}
+			if string(data) != remoteCustomers {
+				t.Errorf("OpenSftpStream() data = %v, want %v", string(data), remoteCustomers)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"github.com/hellgate75/go-invite-customers/io"
+	"github.com/hellgate75/go-invite-customers/model"
+	io2 "io"
+	"sync"
+)
+
+//  Receiver of the invitation decisions, called as soon as each customer is decided.
+//
+//  Methods are called concurrently by the evaluation workers, so implementations must be
+//  safe for concurrent use.
+type ResultSink interface {
+	//  Receive a customer within the invitation criteria, with the assigned venue if any
+	Invited(customer model.CustomerDetails)
+	//  Receive a customer outside the invitation criteria
+	Excluded(customer model.CustomerDetails)
+	//  Receive a customer that cannot be evaluated, with the rejection reason
+	Rejected(customer model.CustomerOffice, reason error)
+}
+
+// Default sink, collecting the decisions in the output data lists
+type outputSink struct {
+	out *OutputData
+}
+
+func (s *outputSink) Invited(customer model.CustomerDetails) {
+	if s.out.IsComplete {
+		if customer.Venue != "" {
+			s.out.Complete.AddInvitedToVenue(customer.Venue, &customer)
+		} else {
+			s.out.Complete.AddInvited(&customer)
+		}
+	} else {
+		if customer.Venue != "" {
+			s.out.Simple.AddToVenue(customer.Venue, &customer)
+		} else {
+			s.out.Simple.Add(&customer)
+		}
+	}
+}
+
+func (s *outputSink) Excluded(customer model.CustomerDetails) {
+	if s.out.IsComplete {
+		s.out.Complete.AddExcluded(&customer)
+	}
+}
+
+func (s *outputSink) Rejected(customer model.CustomerOffice, reason error) {
+	// Rejections are also reported in the scan errors
+	if s.out.IsComplete {
+		s.out.Complete.AddRejected(model.ToRejectedData(&customer, reason))
+	}
+}
+
+//  Sink writing each decision as a newline-delimited JSON line, as soon as it is made, so the
+//  results can be consumed while the scan runs.
+//
+//  The excluded and rejected customers are written only for the detailed output, the first
+//  write error stops the writing and is reported by the Err method.
+type NdjsonSink struct {
+	m        sync.Mutex
+	w        io2.Writer
+	detailed bool
+	err      error
+}
+
+//  Create a sink writing the decisions as newline-delimited JSON lines.
+//
+//  W/
+//  Writer of the lines, each line is written with a single call
+//
+//  Detailed/
+//  Write the excluded and rejected customers too, instead of only the invited ones
+//
+//  The output is the sink, to be used as ResultSink in the InputData.
+func NewNdjsonSink(w io2.Writer, detailed bool) *NdjsonSink {
+	return &NdjsonSink{w: w, detailed: detailed}
+}
+
+func (s *NdjsonSink) Invited(customer model.CustomerDetails) {
+	s.write(io.Decision{
+		Decision: io.InvitedDecision,
+		UserId:   customer.UserId,
+		Name:     customer.Name,
+		Venue:    customer.Venue,
+		Location: customer.Location,
+	})
+}
+
+func (s *NdjsonSink) Excluded(customer model.CustomerDetails) {
+	if s.detailed {
+		s.write(io.Decision{
+			Decision: io.ExcludedDecision,
+			UserId:   customer.UserId,
+			Name:     customer.Name,
+			Location: customer.Location,
+		})
+	}
+}
+
+func (s *NdjsonSink) Rejected(customer model.CustomerOffice, reason error) {
+	if s.detailed {
+		decision := io.Decision{
+			Decision: io.RejectedDecision,
+			UserId:   customer.UserId,
+			Name:     customer.Name,
+		}
+		if reason != nil {
+			decision.Reason = reason.Error()
+		}
+		s.write(decision)
+	}
+}
+
+// Get the first error arisen writing the lines, if any
+func (s *NdjsonSink) Err() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.err
+}
+
+func (s *NdjsonSink) write(decision io.Decision) {
+	line, err := io.EncodeDecision(decision)
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.err != nil {
+		return
+	}
+	if err == nil {
+		_, err = s.w.Write(line)
+	}
+	s.err = err
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package invite
+
+import (
+	"bytes"
+	"errors"
+	"github.com/google/uuid"
+	io2 "github.com/hellgate75/go-invite-customers/io"
+	"github.com/hellgate75/go-invite-customers/model"
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+type recordingSink struct {
+	m        sync.Mutex
+	invited  []model.CustomerDetails
+	excluded []model.CustomerDetails
+	rejected []model.CustomerOffice
+}
+
+func (s *recordingSink) Invited(customer model.CustomerDetails) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.invited = append(s.invited, customer)
+}
+
+func (s *recordingSink) Excluded(customer model.CustomerDetails) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.excluded = append(s.excluded, customer)
+}
+
+func (s *recordingSink) Rejected(customer model.CustomerOffice, reason error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.rejected = append(s.rejected, customer)
+}
+
+func TestExecuteInviteScan_ResultSink(t *testing.T) {
+	file, err := ioutil.TempFile("", uuid.New().String())
+	if err != nil {
+		t.Errorf("TempFile() error = %v, creating test file", err)
+		return
+	}
+	name := file.Name()
+	defer func() {
+		_ = DeleteTestFile(name)
+	}()
+	_, _ = file.Write([]byte("{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}\n" +
+		"{\"latitude\": \"50.339428\", \"user_id\": 1, \"name\": \"Michael Barret\", \"longitude\": \"-3.257664\"}\n" +
+		"{\"latitude\": \"north\", \"user_id\": 7, \"name\": \"James Barret\", \"longitude\": \"-3.257664\"}\n"))
+	_ = file.Close()
+	sink := &recordingSink{}
+	gotOut, gotErrs := ExecuteInviteScan(InputData{
+		UseDetailedOutput: true,
+		Distance:          100,
+		MeasureUnit:       "K",
+		HomeLongitude:     -6.257664,
+		HomeLatitude:      53.339428,
+		InputEncoding:     io2.JsonEncoding,
+		OutputEncoding:    io2.JsonEncoding,
+		SilentOutput:      true,
+		FileOrStream:      name,
+		UsePerLineInput:   true,
+		Sink:              sink,
+	})
+	if len(gotErrs) != 1 {
+		t.Errorf("ExecuteInviteScan() gotErrs = %v, want %v errors", gotErrs, 1)
+	}
+	if len(sink.invited) != 1 || sink.invited[0].UserId != 12 {
+		t.Errorf("ResultSink.Invited() got = %+v, want customer %v", sink.invited, 12)
+	}
+	if len(sink.excluded) != 1 || sink.excluded[0].UserId != 1 {
+		t.Errorf("ResultSink.Excluded() got = %+v, want customer %v", sink.excluded, 1)
+	}
+	if len(sink.rejected) != 1 || sink.rejected[0].UserId != 7 {
+		t.Errorf("ResultSink.Rejected() got = %+v, want customer %v", sink.rejected, 7)
+	}
+	if len(gotOut.Complete.MatchingCustomerIds) != 0 || len(gotOut.Complete.UnMatchingCustomerIds) != 0 {
+		t.Errorf("ExecuteInviteScan() gotOut Complete = %+v, want empty lists", gotOut.Complete)
+	}
+}
+
+func Test_outputSink(t *testing.T) {
+	tests := []struct {
+		name         string
+		isComplete   bool
+		wantSimple   int
+		wantInvited  int
+		wantExcluded int
+		wantRejected int
+	}{
+		{
+			name:         "Test simple output collects only invited customers",
+			isComplete:   false,
+			wantSimple:   1,
+			wantInvited:  0,
+			wantExcluded: 0,
+		},
+		{
+			name:         "Test detailed output collects invited, excluded and rejected customers",
+			isComplete:   true,
+			wantSimple:   0,
+			wantInvited:  1,
+			wantExcluded: 1,
+			wantRejected: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := newOutputData(InputData{UseDetailedOutput: tt.isComplete})
+			sink := &outputSink{&out}
+			sink.Invited(model.CustomerDetails{UserId: 1, Name: "Thomas Barret"})
+			sink.Excluded(model.CustomerDetails{UserId: 2, Name: "Michael Barret"})
+			sink.Rejected(model.CustomerOffice{UserId: 3, Name: "James Barret"}, errors.New("invalid latitude"))
+			if len(out.Simple.CustomerIds) != tt.wantSimple {
+				t.Errorf("outputSink Simple.CustomerIds = %+v, want %v customers", out.Simple.CustomerIds, tt.wantSimple)
+			}
+			if len(out.Complete.MatchingCustomerIds) != tt.wantInvited {
+				t.Errorf("outputSink Complete.MatchingCustomerIds = %+v, want %v customers", out.Complete.MatchingCustomerIds, tt.wantInvited)
+			}
+			if len(out.Complete.UnMatchingCustomerIds) != tt.wantExcluded {
+				t.Errorf("outputSink Complete.UnMatchingCustomerIds = %+v, want %v customers", out.Complete.UnMatchingCustomerIds, tt.wantExcluded)
+			}
+			if len(out.Complete.RejectedCustomerIds) != tt.wantRejected {
+				t.Errorf("outputSink Complete.RejectedCustomerIds = %+v, want %v customers", out.Complete.RejectedCustomerIds, tt.wantRejected)
+			} else if tt.wantRejected > 0 && out.Complete.RejectedCustomerIds[0].Reason != "invalid latitude" {
+				t.Errorf("outputSink Complete.RejectedCustomerIds reason = %v, want %v", out.Complete.RejectedCustomerIds[0].Reason, "invalid latitude")
+			}
+		})
+	}
+}
+
+func TestNdjsonSink(t *testing.T) {
+	tests := []struct {
+		name      string
+		detailed  bool
+		wantLines string
+	}{
+		{
+			name:      "Test simple output writes only invited customers",
+			detailed:  false,
+			wantLines: "{\"decision\":\"invited\",\"user_id\":1,\"name\":\"Thomas Barret\",\"venue\":\"Dublin\"}\n",
+		},
+		{
+			name:     "Test detailed output writes all decisions",
+			detailed: true,
+			wantLines: "{\"decision\":\"invited\",\"user_id\":1,\"name\":\"Thomas Barret\",\"venue\":\"Dublin\"}\n" +
+				"{\"decision\":\"excluded\",\"user_id\":2,\"name\":\"Michael Barret\",\"location\":{\"latitude\":50.339428,\"longitude\":-3.257664,\"distance\":392.024,\"unit\":\"K\"}}\n" +
+				"{\"decision\":\"rejected\",\"user_id\":3,\"name\":\"James Barret\",\"reason\":\"Invalid coordinates\"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buff := bytes.NewBuffer([]byte{})
+			sink := NewNdjsonSink(buff, tt.detailed)
+			sink.Invited(model.CustomerDetails{UserId: 1, Name: "Thomas Barret", Venue: "Dublin"})
+			sink.Excluded(model.CustomerDetails{UserId: 2, Name: "Michael Barret",
+				Location: &model.CustomerLocation{Latitude: 50.339428, Longitude: -3.257664, Distance: 392.024, Unit: "K"}})
+			sink.Rejected(model.CustomerOffice{UserId: 3, Name: "James Barret"}, errors.New("Invalid coordinates"))
+			if sink.Err() != nil {
+				t.Errorf("NdjsonSink Err() = %v, want nil", sink.Err())
+			}
+			if buff.String() != tt.wantLines {
+				t.Errorf("NdjsonSink lines = %v, want %v", buff.String(), tt.wantLines)
+			}
+		})
+	}
+}
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("closed pipe")
+}
+
+func TestNdjsonSink_writeError(t *testing.T) {
+	w := &failingWriter{}
+	sink := NewNdjsonSink(w, false)
+	sink.Invited(model.CustomerDetails{UserId: 1, Name: "Thomas Barret"})
+	sink.Invited(model.CustomerDetails{UserId: 2, Name: "Michael Barret"})
+	if sink.Err() == nil || w.writes != 1 {
+		t.Errorf("NdjsonSink Err() = %v after %v writes, want error after 1 write", sink.Err(), w.writes)
+	}
+}
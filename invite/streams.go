@@ -9,26 +9,68 @@
 package invite
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 )
 
+// Kind of stream, from the url scheme
+type streamScheme string
+
+const (
+	udpScheme       streamScheme = "udp"
+	tcpScheme       streamScheme = "tcp"
+	udpListenScheme streamScheme = "udp-listen"
+	tcpListenScheme streamScheme = "tcp-listen"
+	httpScheme      streamScheme = "http"
+	ftpScheme       streamScheme = "ftp"
+	sftpScheme      streamScheme = "sftp"
+	fileScheme      streamScheme = "file"
+)
+
+// Max size of the datagrams written to udp outputs
+const maxOutputDatagramSize = 8192
+
+// Get the kind of stream of the url, any url without a known scheme is a file path
+func toStreamScheme(url string) streamScheme {
+	switch {
+	case strings.HasPrefix(url, "udp://"):
+		return udpScheme
+	case strings.HasPrefix(url, "tcp://"):
+		return tcpScheme
+	case strings.HasPrefix(url, "udp-listen://"):
+		return udpListenScheme
+	case strings.HasPrefix(url, "tcp-listen://"):
+		return tcpListenScheme
+	case strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "https://"):
+		return httpScheme
+	case strings.HasPrefix(url, "ftp://"):
+		return ftpScheme
+	case strings.HasPrefix(url, "sftp://"):
+		return sftpScheme
+	default:
+		return fileScheme
+	}
+}
+
 //  Open Url from given path
 //
 //  Url/
-//  Input url (http://...., https://....., ftp://...., sftp://.....
+//  Input url (http://...., https://.....), ftp and sftp urls are opened by OpenFtpStream and OpenSftpStream
 //
 //  The output are the call response  (to close), the input reader and the error, if any error occurs during the stream opening operation.
 func OpenUrlStream(url string) (resp *http.Response, reader io.Reader, err error) {
 	if url == "" {
-		return nil, nil, errors.New(fmt.Sprint("Empty http/ftp url"))
+		return nil, nil, errors.New(fmt.Sprint("Empty http url"))
 	}
-	if url[:7] != "http://" && url[:8] != "https://" && url[:6] != "ftp://" && url[:7] != "sftp://" {
-		return nil, nil, errors.New(fmt.Sprintf("Invalid http/ftp url: %s", url))
+	if toStreamScheme(url) != httpScheme {
+		return nil, nil, errors.New(fmt.Sprintf("Invalid http url: %s", url))
 	}
 	resp, err = http.Get(url)
 	if err != nil {
@@ -100,3 +142,113 @@ func OpenUdpStream(udpUrl string) (conn net.Conn, reader io.Reader, err error) {
 	reader = conn
 	return conn, reader, err
 }
+
+//  Open the output destination from given url or file path
+//
+//  Url/
+//  Output url (udp://host:port, tcp://host:port, http://...., https://....) or file path, that
+//  is created or truncated
+//
+//  ContentType/
+//  Content type of the data posted to the http urls
+//
+//  The output are the destination writer, to close for completing the output, and the error, if
+//  any error occurs during the stream opening operation. Http destinations receive the data as
+//  the body of a single POST request, completed by the Close call, that reports any not 2xx
+//  response status. Udp destinations receive the data in datagrams, split at the line ends.
+func OpenOutputStream(url string, contentType string) (io.WriteCloser, error) {
+	if url == "" {
+		return nil, errors.New(fmt.Sprint("Empty output url"))
+	}
+	switch toStreamScheme(url) {
+	case udpScheme:
+		conn, err := net.Dial("udp", url[6:])
+		if err != nil {
+			return nil, err
+		}
+		return &udpOutputStream{conn: conn}, nil
+	case tcpScheme:
+		return net.Dial("tcp", url[6:])
+	case httpScheme:
+		return openHttpOutputStream(url, contentType)
+	case ftpScheme, sftpScheme:
+		return nil, errors.New(fmt.Sprintf("Unsupported output url: %s", url))
+	default:
+		return os.Create(url)
+	}
+}
+
+// Writer of udp datagrams, buffering the data up to the max datagram size
+type udpOutputStream struct {
+	conn net.Conn
+	buff bytes.Buffer
+}
+
+func (u *udpOutputStream) Write(p []byte) (int, error) {
+	u.buff.Write(p)
+	for u.buff.Len() > maxOutputDatagramSize {
+		data := u.buff.Bytes()[:maxOutputDatagramSize]
+		// Splitting at the last line end, when possible
+		if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+			data = data[:i+1]
+		}
+		if _, err := u.conn.Write(data); err != nil {
+			return 0, err
+		}
+		u.buff.Next(len(data))
+	}
+	return len(p), nil
+}
+
+func (u *udpOutputStream) Close() error {
+	var err error
+	if u.buff.Len() > 0 {
+		_, err = u.conn.Write(u.buff.Bytes())
+		u.buff.Reset()
+	}
+	if errC := u.conn.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+// Writer of the body of a POST request, sent while the data is written
+type httpOutputStream struct {
+	writer *io.PipeWriter
+	done   chan error
+}
+
+func openHttpOutputStream(url string, contentType string) (io.WriteCloser, error) {
+	reader, writer := io.Pipe()
+	request, err := http.NewRequest(http.MethodPost, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	h := &httpOutputStream{writer: writer, done: make(chan error, 1)}
+	go func() {
+		resp, err := http.DefaultClient.Do(request)
+		if err == nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				err = errors.New(fmt.Sprintf("Output url %s response status: %s", url, resp.Status))
+			}
+		}
+		// Unblocking any pending write
+		_ = reader.CloseWithError(err)
+		h.done <- err
+	}()
+	return h, nil
+}
+
+func (h *httpOutputStream) Write(p []byte) (int, error) {
+	return h.writer.Write(p)
+}
+
+func (h *httpOutputStream) Close() error {
+	_ = h.writer.Close()
+	return <-h.done
+}
@@ -13,8 +13,14 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func CreateTestFile() (*os.File, error) {
@@ -241,6 +247,15 @@ func TestOpenUrlStream(t *testing.T) {
 			wantReader: false,
 			wantResp:   false,
 		},
+		{
+			name: "Test open ftp url data stream",
+			args: args{
+				url: "ftp://localhost/customers.txt",
+			},
+			wantErr:    true,
+			wantReader: false,
+			wantResp:   false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -319,3 +334,111 @@ func TestOpenFileStream(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenOutputStream(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		received <- string(body)
+	}))
+	defer server.Close()
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Errorf("Listen() error = %v", err)
+		return
+	}
+	defer func() {
+		_ = tcpListener.Close()
+	}()
+	go func() {
+		conn, err := tcpListener.Accept()
+		if err != nil {
+			return
+		}
+		body, _ := ioutil.ReadAll(conn)
+		_ = conn.Close()
+		received <- string(body)
+	}()
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Errorf("ListenPacket() error = %v", err)
+		return
+	}
+	defer func() {
+		_ = udpConn.Close()
+	}()
+	go func() {
+		body := ""
+		buff := make([]byte, 65536)
+		for len(body) < maxOutputDatagramSize+2 {
+			n, _, err := udpConn.ReadFrom(buff)
+			if err != nil {
+				return
+			}
+			body += string(buff[:n])
+		}
+		received <- body
+	}()
+	fileName := filepath.Join(os.TempDir(), uuid.New().String())
+	defer func() {
+		_ = DeleteTestFile(fileName)
+	}()
+	// Udp payload larger than a datagram, split at the line end
+	udpData := strings.Repeat("a", maxOutputDatagramSize-10) + "\n" + strings.Repeat("b", 11) + "\n"
+	tests := []struct {
+		name         string
+		url          string
+		data         string
+		wantOpenErr  bool
+		wantCloseErr bool
+		wantReceived bool
+	}{
+		{"Test http output", server.URL + "/invite", "{\"customers_list\":[]}\n", false, false, true},
+		{"Test http output error status", server.URL + "/fail", "{}\n", false, true, true},
+		{"Test tcp output", "tcp://" + tcpListener.Addr().String(), "[1] Thomas Barret\n", false, false, true},
+		{"Test udp output", "udp://" + udpConn.LocalAddr().String(), udpData, false, false, true},
+		{"Test file output", fileName, "[1] Thomas Barret\n", false, false, false},
+		{"Test ftp output", "ftp://localhost/out.txt", "", true, false, false},
+		{"Test sftp output", "sftp://localhost/out.txt", "", true, false, false},
+		{"Test empty output", "", "", true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OpenOutputStream(tt.url, "application/json")
+			if (err != nil) != tt.wantOpenErr {
+				t.Errorf("OpenOutputStream() error = %v, wantErr %v", err, tt.wantOpenErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if _, err = got.Write([]byte(tt.data)); err != nil {
+				t.Errorf("OpenOutputStream() Write() error = %v", err)
+			}
+			if err = got.Close(); (err != nil) != tt.wantCloseErr {
+				t.Errorf("OpenOutputStream() Close() error = %v, wantErr %v", err, tt.wantCloseErr)
+			}
+			var body string
+			if tt.wantReceived {
+				select {
+				case body = <-received:
+				case <-time.After(5 * time.Second):
+					t.Errorf("OpenOutputStream() data not received")
+					return
+				}
+			} else {
+				data, _ := ioutil.ReadFile(tt.url)
+				body = string(data)
+			}
+			if body != tt.data {
+				t.Errorf("OpenOutputStream() received = %q, want %q", body, tt.data)
+			}
+		})
+	}
+}
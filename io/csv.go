@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package io
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/hellgate75/go-invite-customers/model"
+	"strconv"
+	"strings"
+)
+
+// Describe which columns of a csv or tsv document contain the customer office fields. Each
+// column is a header name (case insensitive) or a zero-based column index, empty values use
+// the default user_id, name, latitude and longitude columns
+type CsvMapping struct {
+	UserId    string
+	Name      string
+	Latitude  string
+	Longitude string
+	// The document has no header line, so the columns must be indexes
+	NoHeader bool
+}
+
+// Default mapping column names, in their default order
+var CsvColumns = []string{"user_id", "name", "latitude", "longitude"}
+
+//  Parse the columns mapping text, in the format field=column separated by commas, where field
+//  is one of user_id, name, latitude or longitude and column is a header name or a zero-based
+//  column index, or report the arisen error.
+//
+//  In/
+//  mapping text to be converted to CsvMapping type (e.g.: user_id=id,latitude=lat,longitude=lng)
+//
+//  The output are the mapping and the error, if the mapping text is not valid.
+func ParseCsvMapping(in string) (mapping CsvMapping, err error) {
+	for _, item := range strings.Split(in, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return mapping, errors.New(fmt.Sprintf("Invalid csv column mapping: %s", item))
+		}
+		column := strings.TrimSpace(parts[1])
+		switch strings.ToLower(strings.TrimSpace(parts[0])) {
+		case "user_id":
+			mapping.UserId = column
+		case "name":
+			mapping.Name = column
+		case "latitude":
+			mapping.Latitude = column
+		case "longitude":
+			mapping.Longitude = column
+		default:
+			return mapping, errors.New(fmt.Sprintf("Unknown csv column mapping field: %s", parts[0]))
+		}
+	}
+	return mapping, err
+}
+
+func (m CsvMapping) columns() []string {
+	columns := []string{m.UserId, m.Name, m.Latitude, m.Longitude}
+	for i, column := range columns {
+		if column == "" {
+			if m.NoHeader {
+				columns[i] = strconv.Itoa(i)
+			} else {
+				columns[i] = CsvColumns[i]
+			}
+		}
+	}
+	return columns
+}
+
+// Stateful decoder of csv or tsv customer office records, one line at a time, keeping track
+// of the header and of the line number. Quoted fields cannot span multiple lines.
+type CsvDecoder struct {
+	mapping CsvMapping
+	comma   rune
+	indexes []int
+	// The header cannot be decoded, so no record can be decoded
+	headerFailed bool
+	line         int
+	offset       int64
+}
+
+//  Create a decoder of the csv or tsv lines, using the given columns mapping.
+//
+//  Enc/
+//  Encoding format, CsvEncoding or TsvEncoding
+//
+//  Mapping/
+//  Columns mapping, columns are resolved against the header line, when the document has one
+//
+//  The output are the decoder and the error, if the encoding is not csv or tsv or the mapping
+//  uses column names on a document without header.
+func NewCsvDecoder(enc Encoding, mapping CsvMapping) (decoder *CsvDecoder, err error) {
+	var comma rune
+	switch enc {
+	case CsvEncoding:
+		comma = ','
+	case TsvEncoding:
+		comma = '\t'
+	default:
+		return nil, errors.New(fmt.Sprintf("Unknown delimited encoding format %v", enc))
+	}
+	decoder = &CsvDecoder{
+		mapping: mapping,
+		comma:   comma,
+	}
+	if mapping.NoHeader {
+		// Without header, the columns are known from the start
+		indexes := make([]int, 0)
+		for _, column := range mapping.columns() {
+			index, errI := strconv.Atoi(column)
+			if errI != nil || index < 0 {
+				return nil, errors.New(fmt.Sprintf("Csv column %s must be a column index, when no header is available", column))
+			}
+			indexes = append(indexes, index)
+		}
+		decoder.indexes = indexes
+	}
+	return decoder, err
+}
+
+// Get the number of the last decoded line, starting from 1
+func (d *CsvDecoder) Line() int {
+	return d.line
+}
+
+//  Decode the next line of the document, the header line and the blank lines are skipped.
+//
+//  Line/
+//  bytes of the line, without the line terminator
+//
+//  The output are the decoded customer office, the skip flag, that reports a line without a
+//  record, and the model.ParseError, if the record cannot be decoded. The offset of the errors
+//  assumes single character line terminators. After an invalid header line, reported once,
+//  all the following lines are skipped.
+func (d *CsvDecoder) DecodeLine(line []byte) (customer model.CustomerOffice, skip bool, err error) {
+	d.line++
+	offset := d.offset
+	d.offset += int64(len(line)) + 1
+	raw := line
+	line = bytes.TrimRight(line, "\r")
+	if d.headerFailed || len(bytes.TrimSpace(line)) == 0 {
+		return customer, true, err
+	}
+	reader := csv.NewReader(bytes.NewReader(line))
+	reader.Comma = d.comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	record, errR := reader.Read()
+	if errR != nil {
+		d.headerFailed = d.indexes == nil
+		return customer, false, model.NewParseError(d.line, offset, raw, errors.New(fmt.Sprintf("invalid record: %v", errR)))
+	}
+	if d.indexes == nil {
+		// First record is the header
+		if errH := d.readHeader(record); errH != nil {
+			d.headerFailed = true
+			return customer, true, model.NewParseError(d.line, offset, raw, errH)
+		}
+		return customer, true, err
+	}
+	values := make([]string, 0)
+	for i, index := range d.indexes {
+		if index >= len(record) {
+			return customer, false, model.NewParseError(d.line, offset, raw, errors.New(fmt.Sprintf("missing column %s", d.mapping.columns()[i])))
+		}
+		values = append(values, strings.TrimSpace(record[index]))
+	}
+	userId, errU := strconv.ParseInt(values[0], 10, 64)
+	if errU != nil {
+		return customer, false, model.NewParseError(d.line, offset, raw, errors.New(fmt.Sprintf("invalid user id: %s", values[0])))
+	}
+	customer = model.CustomerOffice{
+		UserId:    userId,
+		Name:      values[1],
+		Latitude:  values[2],
+		Longitude: values[3],
+	}
+	return customer, false, err
+}
+
+func (d *CsvDecoder) readHeader(record []string) error {
+	indexes := make([]int, 0)
+	for _, column := range d.mapping.columns() {
+		index := -1
+		for i, name := range record {
+			if strings.EqualFold(strings.TrimSpace(name), column) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			if i, errI := strconv.Atoi(column); errI == nil && i >= 0 {
+				index = i
+			}
+		}
+		if index < 0 {
+			return errors.New(fmt.Sprintf("missing column %s in header", column))
+		}
+		indexes = append(indexes, index)
+	}
+	d.indexes = indexes
+	return nil
+}
+
+//  Read the csv or tsv document and decode the model.CustomerOfficeList input data type,
+//  collecting an error for each record that cannot be decoded.
+//
+//  Data/
+//  bytes that defines the whole document
+//
+//  Enc/
+//  Encoding format, CsvEncoding or TsvEncoding
+//
+//  Mapping/
+//  Columns mapping, columns are resolved against the header line, when the document has one
+//
+//  The output are the list of the decoded records and the model.ParseError errors, reporting the
+//  line number of the records that cannot be decoded.
+func ReadCustomerOfficeCsv(data []byte, enc Encoding, mapping CsvMapping) (customers model.CustomerOfficeList, errs []error) {
+	errs = make([]error, 0)
+	customers.List = make([]model.CustomerOffice, 0)
+	decoder, err := NewCsvDecoder(enc, mapping)
+	if err != nil {
+		return customers, append(errs, err)
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		customer, skip, errD := decoder.DecodeLine(line)
+		if errD != nil {
+			if decoder.headerFailed {
+				// Without a valid header no record can be decoded
+				return customers, append(errs, errD)
+			}
+			errs = append(errs, errD)
+		} else if !skip {
+			customers.List = append(customers.List, customer)
+		}
+	}
+	return customers, errs
+}
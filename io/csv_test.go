@@ -0,0 +1,246 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package io
+
+import (
+	"github.com/hellgate75/go-invite-customers/model"
+	"reflect"
+	"testing"
+)
+
+func TestParseCsvMapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		wantMapping CsvMapping
+		wantErr     bool
+	}{
+		{
+			name:        "Parse empty mapping",
+			in:          "",
+			wantMapping: CsvMapping{},
+			wantErr:     false,
+		},
+		{
+			name:        "Parse complete mapping",
+			in:          "user_id=id, name=full name,LATITUDE=lat,longitude=3",
+			wantMapping: CsvMapping{UserId: "id", Name: "full name", Latitude: "lat", Longitude: "3"},
+			wantErr:     false,
+		},
+		{
+			name:        "Parse mapping with unknown field",
+			in:          "user_id=id,email=mail",
+			wantMapping: CsvMapping{UserId: "id"},
+			wantErr:     true,
+		},
+		{
+			name:        "Parse mapping without column",
+			in:          "user_id",
+			wantMapping: CsvMapping{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMapping, err := ParseCsvMapping(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCsvMapping() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotMapping, tt.wantMapping) {
+				t.Errorf("ParseCsvMapping() gotMapping = %+v, want %+v", gotMapping, tt.wantMapping)
+			}
+		})
+	}
+}
+
+func TestNewCsvDecoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		enc     Encoding
+		mapping CsvMapping
+		wantErr bool
+	}{
+		{
+			name:    "Create csv decoder with header",
+			enc:     CsvEncoding,
+			mapping: CsvMapping{UserId: "id"},
+			wantErr: false,
+		},
+		{
+			name:    "Create tsv decoder without header",
+			enc:     TsvEncoding,
+			mapping: CsvMapping{UserId: "3", Name: "2", Latitude: "1", Longitude: "0", NoHeader: true},
+			wantErr: false,
+		},
+		{
+			name:    "Create decoder without header and named columns",
+			enc:     CsvEncoding,
+			mapping: CsvMapping{UserId: "id", NoHeader: true},
+			wantErr: true,
+		},
+		{
+			name:    "Create decoder for not delimited encoding",
+			enc:     JsonEncoding,
+			mapping: CsvMapping{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder, err := NewCsvDecoder(tt.enc, tt.mapping)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCsvDecoder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (decoder == nil) != tt.wantErr {
+				t.Errorf("NewCsvDecoder() decoder = %v, wantErr %v", decoder, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCsvDecoder_DecodeLine(t *testing.T) {
+	decoder, err := NewCsvDecoder(CsvEncoding, CsvMapping{UserId: "id", Latitude: "lat", Longitude: "lng"})
+	if err != nil {
+		t.Errorf("NewCsvDecoder() error = %v", err)
+		return
+	}
+	tests := []struct {
+		name         string
+		line         string
+		wantCustomer model.CustomerOffice
+		wantSkip     bool
+		wantErr      bool
+	}{
+		{
+			name:     "Decode header line",
+			line:     "lng,lat,Name,ID",
+			wantSkip: true,
+		},
+		{
+			name:     "Decode blank line",
+			line:     "  ",
+			wantSkip: true,
+		},
+		{
+			name:         "Decode record line",
+			line:         "-6.257611,53.339111,\"Barret, Thomas\",12\r",
+			wantCustomer: model.CustomerOffice{UserId: 12, Name: "Barret, Thomas", Latitude: "53.339111", Longitude: "-6.257611"},
+		},
+		{
+			name:    "Decode record line with invalid user id",
+			line:    "-6.257611,53.339111,Thomas Barret,twelve",
+			wantErr: true,
+		},
+		{
+			name:    "Decode record line with missing columns",
+			line:    "-6.257611,53.339111",
+			wantErr: true,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCustomer, gotSkip, err := decoder.DecodeLine([]byte(tt.line))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotSkip != tt.wantSkip {
+				t.Errorf("DecodeLine() gotSkip = %v, want %v", gotSkip, tt.wantSkip)
+			}
+			if !reflect.DeepEqual(gotCustomer, tt.wantCustomer) {
+				t.Errorf("DecodeLine() gotCustomer = %+v, want %+v", gotCustomer, tt.wantCustomer)
+			}
+			if decoder.Line() != i+1 {
+				t.Errorf("DecodeLine() Line() = %v, want %v", decoder.Line(), i+1)
+			}
+		})
+	}
+}
+
+func TestCsvDecoder_DecodeLine_invalidHeader(t *testing.T) {
+	decoder, err := NewCsvDecoder(CsvEncoding, CsvMapping{})
+	if err != nil {
+		t.Errorf("NewCsvDecoder() error = %v", err)
+		return
+	}
+	lines := []string{"user_id,name,latitude", "1,Thomas Barret,53.339111", "2,Michael Barret,51.903614"}
+	errs := make([]string, 0)
+	for _, line := range lines {
+		_, skip, err := decoder.DecodeLine([]byte(line))
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else if !skip {
+			t.Errorf("DecodeLine() line %s decoded, want skipped after the invalid header", line)
+		}
+	}
+	want := []string{"Line 1: missing column longitude in header"}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("DecodeLine() errors = %v, want %v", errs, want)
+	}
+	if decoder.Line() != len(lines) {
+		t.Errorf("DecodeLine() Line() = %v, want %v", decoder.Line(), len(lines))
+	}
+}
+
+func TestReadCustomerOfficeCsv(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		enc           Encoding
+		mapping       CsvMapping
+		wantCustomers model.CustomerOfficeList
+		wantErrs      []string
+	}{
+		{
+			name:    "Read csv document with default columns",
+			data:    "user_id,name,latitude,longitude\n1,Thomas Barret,53.339111,-6.257611\n",
+			enc:     CsvEncoding,
+			mapping: CsvMapping{},
+			wantCustomers: model.CustomerOfficeList{List: []model.CustomerOffice{
+				{UserId: 1, Name: "Thomas Barret", Latitude: "53.339111", Longitude: "-6.257611"},
+			}},
+			wantErrs: []string{},
+		},
+		{
+			name:    "Read tsv document without header",
+			data:    "1\tThomas Barret\t53.339111\t-6.257611\nx\tMichael Barret\t50.339428\t-3.257664",
+			enc:     TsvEncoding,
+			mapping: CsvMapping{NoHeader: true},
+			wantCustomers: model.CustomerOfficeList{List: []model.CustomerOffice{
+				{UserId: 1, Name: "Thomas Barret", Latitude: "53.339111", Longitude: "-6.257611"},
+			}},
+			wantErrs: []string{"Line 2: invalid user id: x"},
+		},
+		{
+			name:          "Read csv document with missing header column",
+			data:          "user_id,name,latitude\n1,Thomas Barret,53.339111\n",
+			enc:           CsvEncoding,
+			mapping:       CsvMapping{},
+			wantCustomers: model.CustomerOfficeList{List: []model.CustomerOffice{}},
+			wantErrs:      []string{"Line 1: missing column longitude in header"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCustomers, gotErrs := ReadCustomerOfficeCsv([]byte(tt.data), tt.enc, tt.mapping)
+			if !reflect.DeepEqual(gotCustomers, tt.wantCustomers) {
+				t.Errorf("ReadCustomerOfficeCsv() gotCustomers = %+v, want %+v", gotCustomers, tt.wantCustomers)
+			}
+			errs := make([]string, 0)
+			for _, err := range gotErrs {
+				errs = append(errs, err.Error())
+			}
+			if !reflect.DeepEqual(errs, tt.wantErrs) {
+				t.Errorf("ReadCustomerOfficeCsv() gotErrs = %v, want %v", errs, tt.wantErrs)
+			}
+		})
+	}
+}
@@ -9,27 +9,35 @@
 package io
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"github.com/hellgate75/go-invite-customers/model"
 	"gopkg.in/yaml.v2"
+	"mime"
 	"strings"
 )
 
 type Encoding string
 
 const (
-	JsonEncoding    Encoding = "json"
-	YamlEncoding    Encoding = "yaml"
-	XmlEncoding     Encoding = "xml"
-	TextEncoding    Encoding = "text"
-	UnknownEncoding Encoding = "unknown"
+	JsonEncoding     Encoding = "json"
+	YamlEncoding     Encoding = "yaml"
+	XmlEncoding      Encoding = "xml"
+	TextEncoding     Encoding = "text"
+	CsvEncoding      Encoding = "csv"
+	TsvEncoding      Encoding = "tsv"
+	MarkdownEncoding Encoding = "markdown"
+	HtmlEncoding     Encoding = "html"
+	GeoJsonEncoding  Encoding = "geojson"
+	NdjsonEncoding   Encoding = "ndjson"
+	UnknownEncoding  Encoding = "unknown"
 )
 
-var InputEncoding = []string{"json", "yaml", "xml"}
-var OutputEncoding = []string{"text", "json", "yaml", "xml"}
+var InputEncoding = []string{"json", "yaml", "xml", "csv", "tsv"}
+var OutputEncoding = []string{"text", "json", "yaml", "xml", "csv", "markdown", "html", "geojson", "ndjson"}
 
 //  Convert text to Encoding or return an unknown Encoding error.
 //
@@ -51,6 +59,24 @@ func ToEncoding(in string) (enc Encoding, err error) {
 	case "text":
 		enc = TextEncoding
 		break
+	case "csv":
+		enc = CsvEncoding
+		break
+	case "tsv":
+		enc = TsvEncoding
+		break
+	case "markdown", "md":
+		enc = MarkdownEncoding
+		break
+	case "html":
+		enc = HtmlEncoding
+		break
+	case "geojson":
+		enc = GeoJsonEncoding
+		break
+	case "ndjson":
+		enc = NdjsonEncoding
+		break
 	default:
 		enc = UnknownEncoding
 		err = errors.New(fmt.Sprintf("Unknown encoding text: %s", in))
@@ -58,6 +84,73 @@ func ToEncoding(in string) (enc Encoding, err error) {
 	return enc, err
 }
 
+//  Get the media type of the data in the given encoding, used when sending data over http.
+//
+//  Enc/
+//  Encoding format, accordingly to the type io.Encoding
+//
+//  The output is the media type, generic binary data for unknown encodings.
+func ContentType(enc Encoding) string {
+	switch enc {
+	case JsonEncoding:
+		return "application/json"
+	case YamlEncoding:
+		return "application/x-yaml"
+	case XmlEncoding:
+		return "application/xml"
+	case TextEncoding:
+		return "text/plain; charset=utf-8"
+	case CsvEncoding:
+		return "text/csv; charset=utf-8"
+	case TsvEncoding:
+		return "text/tab-separated-values; charset=utf-8"
+	case MarkdownEncoding:
+		return "text/markdown; charset=utf-8"
+	case HtmlEncoding:
+		return "text/html; charset=utf-8"
+	case GeoJsonEncoding:
+		return "application/geo+json"
+	case NdjsonEncoding:
+		return "application/x-ndjson"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Media types accepted for an encoding, besides the one reported by ContentType
+var contentTypeAliases = map[string]Encoding{
+	"application/yaml": YamlEncoding,
+	"text/yaml":        YamlEncoding,
+	"text/x-yaml":      YamlEncoding,
+	"text/xml":         XmlEncoding,
+}
+
+//  Convert a media type, as the one of the http Content-Type and Accept headers, to Encoding or
+//  return an unknown Encoding error.
+//
+//  ContentType/
+//  media type, parameters like charset are ignored (e.g.: text/csv; charset=utf-8)
+//
+//  The output are the encoding element and the error, if the media type is not known.
+func ToContentTypeEncoding(contentType string) (enc Encoding, err error) {
+	mediaType, _, errM := mime.ParseMediaType(contentType)
+	if errM != nil {
+		return UnknownEncoding, errors.New(fmt.Sprintf("Invalid media type: %s", contentType))
+	}
+	for _, names := range [][]string{OutputEncoding, InputEncoding} {
+		for _, name := range names {
+			candidate, _ := ToEncoding(name)
+			if known, _, _ := mime.ParseMediaType(ContentType(candidate)); known == mediaType {
+				return candidate, err
+			}
+		}
+	}
+	if alias, ok := contentTypeAliases[mediaType]; ok {
+		return alias, err
+	}
+	return UnknownEncoding, errors.New(fmt.Sprintf("Unknown media type: %s", mediaType))
+}
+
 //  Read the input bytes and decode in the wanted format the wanted model.CustomerOffice input
 //  data type, or report the arisen error.
 //
@@ -76,6 +169,12 @@ func ReadCustomerOffice(data []byte, enc Encoding) (customer model.CustomerOffic
 		err = yaml.Unmarshal(data, &customer)
 	case XmlEncoding:
 		err = xml.Unmarshal(data, &customer)
+	case CsvEncoding, TsvEncoding:
+		// A single record, without header, in the default columns order
+		var decoder *CsvDecoder
+		if decoder, err = NewCsvDecoder(enc, CsvMapping{NoHeader: true}); err == nil {
+			customer, _, err = decoder.DecodeLine(data)
+		}
 	default:
 		err = errors.New(fmt.Sprintf("Unknown encoding format %v", enc))
 	}
@@ -96,17 +195,93 @@ func ReadCustomerOfficeList(data []byte, enc Encoding) (customer model.CustomerO
 	switch enc {
 	case JsonEncoding:
 		err = json.Unmarshal(data, &customer)
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			// The type errors of a customer record are relative to the record
+			if start := jsonFailedRecordOffset(data); start >= 0 {
+				typeError.Offset += start
+			}
+		}
 	case YamlEncoding:
 		err = yaml.Unmarshal(data, &customer)
 	case XmlEncoding:
 		err = xml.Unmarshal(data, &customer)
+	case CsvEncoding, TsvEncoding:
+		// A document with header, using the default columns names, reporting the first invalid record
+		var errs []error
+		if customer, errs = ReadCustomerOfficeCsv(data, enc, CsvMapping{}); len(errs) > 0 {
+			err = errs[0]
+		}
 	default:
 		err = errors.New(fmt.Sprintf("Unknown encoding format %v", enc))
 	}
 	return customer, err
 }
 
-//  Encode the model.InviteList output data type, reporting any error arisen during the encoding
+// Offset in the json document of the first customer record that cannot be decoded, or -1 when
+// the records are all valid or the document has not the expected structure
+func jsonFailedRecordOffset(data []byte) int64 {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return -1
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return -1
+		}
+		if name, _ := key.(string); !strings.EqualFold(name, "customers") {
+			var value json.RawMessage
+			if err = decoder.Decode(&value); err != nil {
+				return -1
+			}
+			continue
+		}
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return -1
+		}
+		for decoder.More() {
+			var record json.RawMessage
+			if err = decoder.Decode(&record); err != nil {
+				return -1
+			}
+			var customer model.CustomerOffice
+			if json.Unmarshal(record, &customer) != nil {
+				// The decoder stands at the end of the record
+				return decoder.InputOffset() - int64(len(record))
+			}
+		}
+		return -1
+	}
+	return -1
+}
+
+//  Read the input bytes and decode in the wanted format the wanted model.VenueList input
+//  data type, or report the arisen error.
+//
+//  Data/
+//  bytes that defines the input data to be un-marshalled from the given encoding format
+//
+//  Enc/
+//  Encoding format, accordingly to the type io.Encoding
+//
+//  The output are the decoded object and the error, if occurred during the decoding operations.
+func ReadVenueList(data []byte, enc Encoding) (venues model.VenueList, err error) {
+	switch enc {
+	case JsonEncoding:
+		err = json.Unmarshal(data, &venues)
+	case YamlEncoding:
+		err = yaml.Unmarshal(data, &venues)
+	case XmlEncoding:
+		err = xml.Unmarshal(data, &venues)
+	default:
+		err = errors.New(fmt.Sprintf("Unknown encoding format %v", enc))
+	}
+	return venues, err
+}
+
+//  Encode the model.InviteList output data type, reporting any error arisen during the encoding,
+//  as EncodeInviteList does.
 //
 //  Invite/
 //  The model.InviteList data type instance to be converted in the given encoding format
@@ -116,16 +291,53 @@ func ReadCustomerOfficeList(data []byte, enc Encoding) (customer model.CustomerO
 //
 //  The output are the byte array and the error, if occurred during the encoding operations.
 func EncodeCustomerInvite(invite model.InviteList, enc Encoding) (data []byte, err error) {
+	return EncodeInviteList(&invite, enc)
+}
+
+//  Encode the model.CompleteInviteList output data type, reporting any error arisen during the
+//  encoding, as EncodeCompleteInviteList does.
+//
+//  Invite/
+//  The model.CompleteInviteList data type instance to be converted in the given encoding format
+//
+//  Enc/
+//  Encoding format, accordingly to the type io.Encoding
+//
+//  The output are the byte array and the error, if occurred during the encoding operations.
+func EncodeCustomerDetailedInvite(invite model.CompleteInviteList, enc Encoding) (data []byte, err error) {
+	return EncodeCompleteInviteList(&invite, enc)
+}
+
+//  Encode the model.InviteList output data type, reporting any error arisen during the encoding
+//
+//  Invite/
+//  Pointer to the model.InviteList data type instance to be converted in the given encoding format
+//
+//  Enc/
+//  Encoding format, accordingly to the type io.Encoding
+//
+//  The output are the byte array and the error, if occurred during the encoding operations.
+func EncodeInviteList(invite *model.InviteList, enc Encoding) (data []byte, err error) {
 	data = make([]byte, 0)
 	switch enc {
 	case JsonEncoding:
-		data, err = json.Marshal(&invite)
+		data, err = json.Marshal(invite)
 	case YamlEncoding:
-		data, err = yaml.Marshal(&invite)
+		data, err = yaml.Marshal(invite)
 	case XmlEncoding:
-		data, err = xml.Marshal(&invite)
+		data, err = xml.Marshal(invite)
 	case TextEncoding:
 		data, err = textEncodeInviteList(invite)
+	case CsvEncoding:
+		data, err = csvEncodeTable(newReportTable(invite.CustomerIds, invite.Venues, nil, nil, false))
+	case MarkdownEncoding:
+		data, err = markdownEncodeTable(newReportTable(invite.CustomerIds, invite.Venues, nil, nil, false))
+	case HtmlEncoding:
+		data, err = htmlEncodeTable(newReportTable(invite.CustomerIds, invite.Venues, nil, nil, false), false)
+	case GeoJsonEncoding:
+		data, err = geoJsonEncode(invite.CustomerIds, invite.Venues, nil, nil, GeoJsonLayout{})
+	case NdjsonEncoding:
+		data, err = ndjsonEncode(invite.CustomerIds, invite.Venues, nil, nil)
 	default:
 		err = errors.New(fmt.Sprintf("Unknown encoding format %v", enc))
 	}
@@ -135,35 +347,70 @@ func EncodeCustomerInvite(invite model.InviteList, enc Encoding) (data []byte, e
 //  Encode the model.CompleteInviteList output data type, reporting any error arisen during the encoding
 //
 //  Invite/
-//  The model.CompleteInviteList data type instance to be converted in the given encoding format
+//  Pointer to the model.CompleteInviteList data type instance to be converted in the given encoding format
 //
 //  Enc/
 //  Encoding format, accordingly to the type io.Encoding
 //
 //  The output are the byte array and the error, if occurred during the encoding operations.
-func EncodeCustomerDetailedInvite(invite model.CompleteInviteList, enc Encoding) (data []byte, err error) {
+func EncodeCompleteInviteList(invite *model.CompleteInviteList, enc Encoding) (data []byte, err error) {
 	data = make([]byte, 0)
 	switch enc {
 	case JsonEncoding:
-		data, err = json.Marshal(&invite)
+		data, err = json.Marshal(invite)
 	case YamlEncoding:
-		data, err = yaml.Marshal(&invite)
+		data, err = yaml.Marshal(invite)
 	case XmlEncoding:
-		data, err = xml.Marshal(&invite)
+		data, err = xml.Marshal(invite)
 	case TextEncoding:
 		data, err = textEncodeCompleteInviteList(invite)
+	case CsvEncoding:
+		data, err = csvEncodeTable(newReportTable(invite.MatchingCustomerIds, invite.Venues, invite.UnMatchingCustomerIds, invite.RejectedCustomerIds, true))
+	case MarkdownEncoding:
+		data, err = markdownEncodeTable(newReportTable(invite.MatchingCustomerIds, invite.Venues, invite.UnMatchingCustomerIds, invite.RejectedCustomerIds, true))
+	case HtmlEncoding:
+		data, err = htmlEncodeTable(newReportTable(invite.MatchingCustomerIds, invite.Venues, invite.UnMatchingCustomerIds, invite.RejectedCustomerIds, true), true)
+	case GeoJsonEncoding:
+		data, err = EncodeCustomerGeoJson(invite, GeoJsonLayout{})
+	case NdjsonEncoding:
+		data, err = ndjsonEncode(invite.MatchingCustomerIds, invite.Venues, invite.UnMatchingCustomerIds, invite.RejectedCustomerIds)
 	default:
 		err = errors.New(fmt.Sprintf("Unknown encoding format %v", enc))
 	}
 	return data, err
 }
 
-func textEncodeInviteList(list model.InviteList) (out []byte, err error) {
+//  Encode the model.ErrorReport output data type, reporting any error arisen during the encoding
+//
+//  Report/
+//  The model.ErrorReport data type instance to be converted in the given encoding format
+//
+//  Enc/
+//  Encoding format, json, yaml or xml accordingly to the type io.Encoding
+//
+//  The output are the byte array and the error, if occurred during the encoding operations.
+func EncodeErrorReport(report model.ErrorReport, enc Encoding) (data []byte, err error) {
+	data = make([]byte, 0)
+	switch enc {
+	case JsonEncoding:
+		data, err = json.Marshal(&report)
+	case YamlEncoding:
+		data, err = yaml.Marshal(&report)
+	case XmlEncoding:
+		data, err = xml.Marshal(&report)
+	default:
+		err = errors.New(fmt.Sprintf("Unknown encoding format %v", enc))
+	}
+	return data, err
+}
+
+func textEncodeInviteList(list *model.InviteList) (out []byte, err error) {
 	out = make([]byte, 0)
-	text := ""
-	for _, c := range list.CustomerIds {
-		text += fmt.Sprintf("[%v] %s\n", c.UserId, c.Name)
+	if len(list.Venues) > 0 {
+		out = append(out, []byte(textEncodeVenues(list.Venues))...)
+		return out, err
 	}
+	text := textEncodeCustomers(list.CustomerIds)
 	if len(text) == 0 {
 		text = "No customer selected"
 	}
@@ -172,25 +419,55 @@ func textEncodeInviteList(list model.InviteList) (out []byte, err error) {
 	return out, err
 }
 
-func textEncodeCompleteInviteList(list model.CompleteInviteList) (out []byte, err error) {
+func textEncodeCompleteInviteList(list *model.CompleteInviteList) (out []byte, err error) {
 	out = make([]byte, 0)
-	text1 := ""
-	for _, c := range list.MatchingCustomerIds {
-		text1 += fmt.Sprintf("[%v] %s\n", c.UserId, c.Name)
-	}
-	if len(text1) == 0 {
-		text1 = "No customer selected"
-	}
-	text1 = "Invite Summary:\n" + text1
-	out = append(out, []byte(text1)...)
-	text2 := ""
-	for _, c := range list.MatchingCustomerIds {
-		text2 += fmt.Sprintf("[%v] %s\n", c.UserId, c.Name)
+	if len(list.Venues) > 0 {
+		out = append(out, []byte(textEncodeVenues(list.Venues))...)
+	} else {
+		text1 := textEncodeCustomers(list.MatchingCustomerIds)
+		if len(text1) == 0 {
+			text1 = "No customer selected\n"
+		}
+		text1 = "Invite Summary:\n" + text1
+		out = append(out, []byte(text1)...)
 	}
+	text2 := textEncodeCustomers(list.UnMatchingCustomerIds)
 	if len(text2) == 0 {
-		text2 = "No customer excluded"
+		text2 = "No customer excluded\n"
 	}
 	text2 = "Exclusion Summary:\n" + text2
 	out = append(out, []byte(text2)...)
+	if len(list.RejectedCustomerIds) > 0 {
+		text3 := "Rejection Summary:\n"
+		for _, c := range list.RejectedCustomerIds {
+			text3 += fmt.Sprintf("[%v] %s - reason: %s\n", c.UserId, c.Name, c.Reason)
+		}
+		out = append(out, []byte(text3)...)
+	}
 	return out, err
 }
+
+func textEncodeVenues(venues []model.VenueInviteList) string {
+	text := ""
+	for _, group := range venues {
+		customers := textEncodeCustomers(group.CustomerIds)
+		if len(customers) == 0 {
+			customers = "No customer selected\n"
+		}
+		text += fmt.Sprintf("Invite Summary for venue %s:\n%s", group.Venue, customers)
+	}
+	return text
+}
+
+func textEncodeCustomers(customers []model.CustomerDetails) string {
+	text := ""
+	for _, c := range customers {
+		if c.Location != nil {
+			text += fmt.Sprintf("[%v] %s - distance: %.3f %s, coordinates: (%v, %v)\n", c.UserId, c.Name,
+				c.Location.Distance, c.Location.Unit, c.Location.Latitude, c.Location.Longitude)
+		} else {
+			text += fmt.Sprintf("[%v] %s\n", c.UserId, c.Name)
+		}
+	}
+	return text
+}
@@ -9,6 +9,7 @@
 package io
 
 import (
+	"errors"
 	"github.com/hellgate75/go-invite-customers/model"
 	"reflect"
 	"testing"
@@ -20,8 +21,13 @@ func TestEncodeCustomerDetailedInvite(t *testing.T) {
 		enc    Encoding
 	}
 	inviteList := *model.NewCompleteInviteList()
-	inviteList.MatchingCustomerIds = []model.CustomerDetails{{1, "Thomas Barret"}}
-	inviteList.UnMatchingCustomerIds = []model.CustomerDetails{{2, "Michael Barret"}}
+	inviteList.MatchingCustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}
+	inviteList.UnMatchingCustomerIds = []model.CustomerDetails{{UserId: 2, Name: "Michael Barret"}}
+	locatedList := *model.NewCompleteInviteList()
+	locatedList.MatchingCustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret",
+		Location: &model.CustomerLocation{Latitude: 53.339111, Longitude: -6.257611, Distance: 0.5, Unit: "K"}}}
+	locatedList.UnMatchingCustomerIds = []model.CustomerDetails{{UserId: 2, Name: "Michael Barret",
+		Location: &model.CustomerLocation{Latitude: 50.339428, Longitude: -3.257664, Distance: 392.25, Unit: "K"}}}
 
 	tests := []struct {
 		name     string
@@ -72,7 +78,63 @@ exclusions_list:
 			wantData: []byte(`Invite Summary:
 [1] Thomas Barret
 Exclusion Summary:
-[1] Thomas Barret
+[2] Michael Barret
+`),
+		},
+		{
+			name: "Encode a located model.CompleteInviteList to JSON format",
+			args: args{
+				invite: locatedList,
+				enc:    JsonEncoding,
+			},
+			wantErr:  false,
+			wantData: []byte("{\"customers_list\":[{\"user_id\":1,\"name\":\"Thomas Barret\",\"location\":{\"latitude\":53.339111,\"longitude\":-6.257611,\"distance\":0.5,\"unit\":\"K\"}}],\"exclusions_list\":[{\"user_id\":2,\"name\":\"Michael Barret\",\"location\":{\"latitude\":50.339428,\"longitude\":-3.257664,\"distance\":392.25,\"unit\":\"K\"}}]}"),
+		},
+		{
+			name: "Encode a located model.CompleteInviteList to XML format",
+			args: args{
+				invite: locatedList,
+				enc:    XmlEncoding,
+			},
+			wantErr:  false,
+			wantData: []byte("<CompleteInviteList><customers-list><user-id>1</user-id><name>Thomas Barret</name><location><latitude>53.339111</latitude><longitude>-6.257611</longitude><distance>0.5</distance><unit>K</unit></location></customers-list><exclusions-list><user-id>2</user-id><name>Michael Barret</name><location><latitude>50.339428</latitude><longitude>-3.257664</longitude><distance>392.25</distance><unit>K</unit></location></exclusions-list></CompleteInviteList>"),
+		},
+		{
+			name: "Encode a located model.CompleteInviteList to YAML format",
+			args: args{
+				invite: locatedList,
+				enc:    YamlEncoding,
+			},
+			wantErr: false,
+			wantData: []byte(`customers_list:
+- user_id: 1
+  name: Thomas Barret
+  location:
+    latitude: 53.339111
+    longitude: -6.257611
+    distance: 0.5
+    unit: K
+exclusions_list:
+- user_id: 2
+  name: Michael Barret
+  location:
+    latitude: 50.339428
+    longitude: -3.257664
+    distance: 392.25
+    unit: K
+`),
+		},
+		{
+			name: "Encode a located model.CompleteInviteList to Text format",
+			args: args{
+				invite: locatedList,
+				enc:    TextEncoding,
+			},
+			wantErr: false,
+			wantData: []byte(`Invite Summary:
+[1] Thomas Barret - distance: 0.500 K, coordinates: (53.339111, -6.257611)
+Exclusion Summary:
+[2] Michael Barret - distance: 392.250 K, coordinates: (50.339428, -3.257664)
 `),
 		},
 		{
@@ -105,7 +167,7 @@ func TestEncodeCustomerInvite(t *testing.T) {
 		enc    Encoding
 	}
 	inviteList := *model.NewInviteList()
-	inviteList.CustomerIds = []model.CustomerDetails{{1, "Thomas Barret"}}
+	inviteList.CustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}
 
 	tests := []struct {
 		name     string
@@ -178,6 +240,48 @@ func TestEncodeCustomerInvite(t *testing.T) {
 	}
 }
 
+func TestEncodeInviteList(t *testing.T) {
+	inviteList := model.NewInviteList()
+	inviteList.CustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}
+	completeList := model.NewCompleteInviteList()
+	completeList.MatchingCustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}
+	tests := []struct {
+		name     string
+		encode   func() ([]byte, error)
+		wantData []byte
+		wantErr  bool
+	}{
+		{
+			name:     "Encode a model.InviteList pointer to JSON format",
+			encode:   func() ([]byte, error) { return EncodeInviteList(inviteList, JsonEncoding) },
+			wantData: []byte("{\"customers_list\":[{\"user_id\":1,\"name\":\"Thomas Barret\"}]}"),
+		},
+		{
+			name:     "Encode a model.CompleteInviteList pointer to JSON format",
+			encode:   func() ([]byte, error) { return EncodeCompleteInviteList(completeList, JsonEncoding) },
+			wantData: []byte("{\"customers_list\":[{\"user_id\":1,\"name\":\"Thomas Barret\"}],\"exclusions_list\":[]}"),
+		},
+		{
+			name:     "Not Encode a model.InviteList pointer to Unknown format",
+			encode:   func() ([]byte, error) { return EncodeInviteList(inviteList, UnknownEncoding) },
+			wantData: []byte{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotData, err := tt.encode()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EncodeInviteList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotData, tt.wantData) {
+				t.Errorf("EncodeInviteList() gotData = %s, want %s", gotData, tt.wantData)
+			}
+		})
+	}
+}
+
 func TestReadCustomerOffice(t *testing.T) {
 	type args struct {
 		data []byte
@@ -204,6 +308,15 @@ func TestReadCustomerOffice(t *testing.T) {
 			wantCustomer: customer,
 			wantErr:      false,
 		},
+		{
+			name: "Test Json Import single model.CustomerOffice data with numeric coordinates",
+			args: args{
+				data: []byte("{\"user_id\":\"1\",\"name\":\"Thomas Barrett\",\"latitude\":10.123456,\"longitude\":-5.98765}"),
+				enc:  JsonEncoding,
+			},
+			wantCustomer: customer,
+			wantErr:      false,
+		},
 		{
 			name: "Test Yaml Import single model.CustomerOffice data",
 			args: args{
@@ -227,6 +340,24 @@ longitude: "-5.98765"
 			wantCustomer: customer,
 			wantErr:      false,
 		},
+		{
+			name: "Test Csv Import single model.CustomerOffice data",
+			args: args{
+				data: []byte("1,Thomas Barrett,10.123456,-5.98765"),
+				enc:  CsvEncoding,
+			},
+			wantCustomer: customer,
+			wantErr:      false,
+		},
+		{
+			name: "Test Tsv Import single model.CustomerOffice data",
+			args: args{
+				data: []byte("1\tThomas Barrett\t10.123456\t-5.98765"),
+				enc:  TsvEncoding,
+			},
+			wantCustomer: customer,
+			wantErr:      false,
+		},
 		{
 			name: "Test Unknown Import single model.CustomerOffice data",
 			args: args{
@@ -293,6 +424,38 @@ func TestToEncoding(t *testing.T) {
 			wantEnc: TextEncoding,
 			wantErr: false,
 		},
+		{
+			name: "Transform correct csv encoding format text",
+			args: args{
+				in: "CSV",
+			},
+			wantEnc: CsvEncoding,
+			wantErr: false,
+		},
+		{
+			name: "Transform correct tsv encoding format text",
+			args: args{
+				in: "tsv",
+			},
+			wantEnc: TsvEncoding,
+			wantErr: false,
+		},
+		{
+			name: "Transform correct markdown encoding format short text",
+			args: args{
+				in: "md",
+			},
+			wantEnc: MarkdownEncoding,
+			wantErr: false,
+		},
+		{
+			name: "Transform correct html encoding format text",
+			args: args{
+				in: "html",
+			},
+			wantEnc: HtmlEncoding,
+			wantErr: false,
+		},
 		{
 			name: "Transform incorrect encoding format text",
 			args: args{
@@ -318,8 +481,8 @@ func TestToEncoding(t *testing.T) {
 
 func Test_textEncodeCompleteInviteList(t *testing.T) {
 	inviteList := model.CompleteInviteList{
-		MatchingCustomerIds:   []model.CustomerDetails{{1, "Thomas Barret"}},
-		UnMatchingCustomerIds: []model.CustomerDetails{{2, "Michael Barret"}},
+		MatchingCustomerIds:   []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}},
+		UnMatchingCustomerIds: []model.CustomerDetails{{UserId: 2, Name: "Michael Barret"}},
 	}
 	type args struct {
 		list model.CompleteInviteList
@@ -339,13 +502,30 @@ func Test_textEncodeCompleteInviteList(t *testing.T) {
 			wantOut: []byte(`Invite Summary:
 [1] Thomas Barret
 Exclusion Summary:
+[2] Michael Barret
+`),
+		},
+		{
+			name: "Test Text Encode model.CompleteInviteList with rejected customers",
+			args: args{
+				list: model.CompleteInviteList{
+					MatchingCustomerIds: []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}},
+					RejectedCustomerIds: []model.RejectedCustomer{{UserId: 3, Name: "Ian Barret", Reason: "invalid latitude"}},
+				},
+			},
+			wantErr: false,
+			wantOut: []byte(`Invite Summary:
 [1] Thomas Barret
+Exclusion Summary:
+No customer excluded
+Rejection Summary:
+[3] Ian Barret - reason: invalid latitude
 `),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotOut, err := textEncodeCompleteInviteList(tt.args.list)
+			gotOut, err := textEncodeCompleteInviteList(&tt.args.list)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("textEncodeCompleteInviteList() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -359,7 +539,7 @@ Exclusion Summary:
 
 func Test_textEncodeInviteList(t *testing.T) {
 	inviteList := model.InviteList{
-		CustomerIds: []model.CustomerDetails{{1, "Thomas Barret"}},
+		CustomerIds: []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}},
 	}
 	type args struct {
 		list model.InviteList
@@ -383,7 +563,7 @@ func Test_textEncodeInviteList(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotOut, err := textEncodeInviteList(tt.args.list)
+			gotOut, err := textEncodeInviteList(&tt.args.list)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("textEncodeInviteList() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -471,3 +651,186 @@ customers:
 		})
 	}
 }
+
+func TestReadVenueList(t *testing.T) {
+	type args struct {
+		data []byte
+		enc  Encoding
+	}
+	venues := model.VenueList{
+		List: []model.Venue{{Name: "Dublin", Latitude: 53.339428, Longitude: -6.257664, Radius: 50}},
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantVenues model.VenueList
+		wantErr    bool
+	}{
+		{
+			name: "Test Json Import model.VenueList data",
+			args: args{
+				data: []byte("{\"venues\":[{\"name\":\"Dublin\",\"latitude\":53.339428,\"longitude\":-6.257664,\"radius\":50}]}"),
+				enc:  JsonEncoding,
+			},
+			wantVenues: venues,
+			wantErr:    false,
+		},
+		{
+			name: "Test Yaml Import model.VenueList data",
+			args: args{
+				data: []byte(`
+venues:
+- name: Dublin
+  latitude: 53.339428
+  longitude: -6.257664
+  radius: 50
+`),
+				enc: YamlEncoding,
+			},
+			wantVenues: venues,
+			wantErr:    false,
+		},
+		{
+			name: "Test Xml Import model.VenueList data",
+			args: args{
+				data: []byte("<VenueList><venues><name>Dublin</name><latitude>53.339428</latitude><longitude>-6.257664</longitude><radius>50</radius></venues></VenueList>"),
+				enc:  XmlEncoding,
+			},
+			wantVenues: venues,
+			wantErr:    false,
+		},
+		{
+			name: "Test Unknown Import model.VenueList data",
+			args: args{
+				data: []byte{},
+				enc:  UnknownEncoding,
+			},
+			wantVenues: model.VenueList{},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotVenues, err := ReadVenueList(tt.args.data, tt.args.enc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadVenueList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotVenues, tt.wantVenues) {
+				t.Errorf("ReadVenueList() gotVenues = %v, want %v", gotVenues, tt.wantVenues)
+			}
+		})
+	}
+}
+
+func Test_textEncodeVenues(t *testing.T) {
+	venues := []model.VenueInviteList{
+		{Venue: "Dublin", CustomerIds: []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}},
+		{Venue: "Cork", CustomerIds: []model.CustomerDetails{}},
+	}
+	want := `Invite Summary for venue Dublin:
+[1] Thomas Barret
+Invite Summary for venue Cork:
+No customer selected
+`
+	if got := textEncodeVenues(venues); got != want {
+		t.Errorf("textEncodeVenues() got = %v, want %v", got, want)
+	}
+}
+
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		enc  Encoding
+		want string
+	}{
+		{"Json content type", JsonEncoding, "application/json"},
+		{"Csv content type", CsvEncoding, "text/csv; charset=utf-8"},
+		{"Ndjson content type", NdjsonEncoding, "application/x-ndjson"},
+		{"Unknown content type", UnknownEncoding, "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContentType(tt.enc); got != tt.want {
+				t.Errorf("ContentType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToContentTypeEncoding(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		wantEnc     Encoding
+		wantErr     bool
+	}{
+		{"Json media type", "application/json", JsonEncoding, false},
+		{"Csv media type with charset", "text/csv; charset=utf-8", CsvEncoding, false},
+		{"Tsv media type", "text/tab-separated-values", TsvEncoding, false},
+		{"Case insensitive xml media type", "Application/XML", XmlEncoding, false},
+		{"Yaml alias media type", "application/yaml", YamlEncoding, false},
+		{"Geojson media type", "application/geo+json", GeoJsonEncoding, false},
+		{"Unknown media type", "image/png", UnknownEncoding, true},
+		{"Invalid media type", "json;", UnknownEncoding, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEnc, err := ToContentTypeEncoding(tt.contentType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToContentTypeEncoding() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotEnc != tt.wantEnc {
+				t.Errorf("ToContentTypeEncoding() gotEnc = %v, want %v", gotEnc, tt.wantEnc)
+			}
+		})
+	}
+}
+
+func TestEncodeErrorReport(t *testing.T) {
+	report := model.NewErrorReport([]error{
+		model.NewParseError(2, 56, []byte("{bad"), errors.New("invalid character")),
+	})
+	tests := []struct {
+		name     string
+		enc      Encoding
+		wantData string
+		wantErr  bool
+	}{
+		{
+			name:     "Encode errors report to JSON format",
+			enc:      JsonEncoding,
+			wantData: "{\"count\":1,\"errors\":[{\"kind\":\"parse\",\"message\":\"Line 2: invalid character\",\"line\":2,\"offset\":56,\"snippet\":\"{bad\"}]}",
+		},
+		{
+			name: "Encode errors report to YAML format",
+			enc:  YamlEncoding,
+			wantData: `count: 1
+errors:
+- kind: parse
+  message: 'Line 2: invalid character'
+  line: 2
+  offset: 56
+  snippet: '{bad'
+`,
+		},
+		{
+			name:    "Encode errors report to Unknown format",
+			enc:     TextEncoding,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotData, err := EncodeErrorReport(report, tt.enc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EncodeErrorReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(gotData) != tt.wantData {
+				t.Errorf("EncodeErrorReport() gotData = %v, want %v", string(gotData), tt.wantData)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package io
+
+import (
+	"encoding/json"
+	"github.com/hellgate75/go-invite-customers/geo"
+	"github.com/hellgate75/go-invite-customers/model"
+)
+
+// Number of sides of the polygons approximating the radius circles
+const geoJsonCircleSegments = 64
+
+// Describe the map elements reported with the customers in the GeoJSON output
+type GeoJsonLayout struct {
+	// Home location or venues, a positive radius is reported as a circle polygon
+	Centres []model.Venue
+	// Measure unit of the centres radius
+	Unit geo.Unit
+	// Invitation area, reported as polygons, if any
+	Geofence *geo.Geofence
+}
+
+type geoJsonGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+type geoJsonFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   *geoJsonGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJsonFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJsonFeature `json:"features"`
+}
+
+//  Encode the model.CompleteInviteList output data type as a GeoJSON FeatureCollection, with a
+//  Point feature for each customer and the map elements of the given layout, reporting any
+//  error arisen during the encoding.
+//
+//  Invite/
+//  The model.CompleteInviteList data type instance, customers without location, as the rejected
+//  ones, have no geometry
+//
+//  Layout/
+//  The home location or venues, with their radius, and the geofence to be reported on the map
+//
+//  The output are the byte array and the error, if occurred during the encoding operations.
+func EncodeCustomerGeoJson(invite *model.CompleteInviteList, layout GeoJsonLayout) (data []byte, err error) {
+	return geoJsonEncode(invite.MatchingCustomerIds, invite.Venues, invite.UnMatchingCustomerIds, invite.RejectedCustomerIds, layout)
+}
+
+func geoJsonEncode(invited []model.CustomerDetails, venues []model.VenueInviteList, excluded []model.CustomerDetails, rejected []model.RejectedCustomer, layout GeoJsonLayout) (data []byte, err error) {
+	collection := geoJsonFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]geoJsonFeature, 0),
+	}
+	role := "home"
+	if len(layout.Centres) > 1 || len(venues) > 0 {
+		role = "venue"
+	}
+	for _, centre := range layout.Centres {
+		properties := map[string]interface{}{
+			"role":   role,
+			"name":   centre.Name,
+			"radius": centre.Radius,
+			"unit":   string(layout.Unit),
+		}
+		collection.Features = append(collection.Features, geoJsonFeature{
+			Type:       "Feature",
+			Geometry:   geoJsonPoint(centre.Latitude, centre.Longitude),
+			Properties: properties,
+		})
+		if centre.Radius > 0 && layout.Unit.IsValid() {
+			circle := geo.Circle(centre.Latitude, centre.Longitude, centre.Radius, layout.Unit, geoJsonCircleSegments)
+			collection.Features = append(collection.Features, geoJsonFeature{
+				Type:       "Feature",
+				Geometry:   geoJsonPolygon(circle),
+				Properties: map[string]interface{}{"role": "radius", "name": centre.Name, "radius": centre.Radius, "unit": string(layout.Unit)},
+			})
+		}
+	}
+	if layout.Geofence != nil {
+		for _, polygon := range layout.Geofence.Polygons {
+			collection.Features = append(collection.Features, geoJsonFeature{
+				Type:       "Feature",
+				Geometry:   geoJsonPolygon(polygon),
+				Properties: map[string]interface{}{"role": "geofence"},
+			})
+		}
+	}
+	if len(venues) > 0 {
+		for _, group := range venues {
+			for _, c := range group.CustomerIds {
+				collection.Features = append(collection.Features, geoJsonCustomer(c, "invited", group.Venue))
+			}
+		}
+	} else {
+		for _, c := range invited {
+			collection.Features = append(collection.Features, geoJsonCustomer(c, "invited", ""))
+		}
+	}
+	for _, c := range excluded {
+		collection.Features = append(collection.Features, geoJsonCustomer(c, "excluded", ""))
+	}
+	for _, c := range rejected {
+		// Rejected customers have no valid position
+		collection.Features = append(collection.Features, geoJsonFeature{
+			Type: "Feature",
+			Properties: map[string]interface{}{
+				"role":    "customer",
+				"user_id": c.UserId,
+				"name":    c.Name,
+				"status":  "rejected",
+				"reason":  c.Reason,
+			},
+		})
+	}
+	return json.Marshal(&collection)
+}
+
+func geoJsonCustomer(c model.CustomerDetails, status string, venue string) geoJsonFeature {
+	feature := geoJsonFeature{
+		Type: "Feature",
+		Properties: map[string]interface{}{
+			"role":    "customer",
+			"user_id": c.UserId,
+			"name":    c.Name,
+			"status":  status,
+		},
+	}
+	if venue != "" {
+		feature.Properties["venue"] = venue
+	}
+	if c.Location != nil {
+		feature.Geometry = geoJsonPoint(c.Location.Latitude, c.Location.Longitude)
+		feature.Properties["distance"] = c.Location.Distance
+		feature.Properties["unit"] = c.Location.Unit
+	}
+	return feature
+}
+
+// GeoJSON positions are in [longitude, latitude] order
+func geoJsonPoint(lat float64, lng float64) *geoJsonGeometry {
+	return &geoJsonGeometry{Type: "Point", Coordinates: []float64{lng, lat}}
+}
+
+func geoJsonPolygon(polygon geo.Polygon) *geoJsonGeometry {
+	rings := make([][][]float64, 0)
+	for _, ring := range polygon.Rings {
+		positions := make([][]float64, 0)
+		for _, p := range ring {
+			positions = append(positions, []float64{p.Longitude, p.Latitude})
+		}
+		rings = append(rings, positions)
+	}
+	return &geoJsonGeometry{Type: "Polygon", Coordinates: rings}
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package io
+
+import (
+	"encoding/json"
+	"github.com/hellgate75/go-invite-customers/geo"
+	"github.com/hellgate75/go-invite-customers/model"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeCustomerGeoJson(t *testing.T) {
+	inviteList := *model.NewCompleteInviteList()
+	inviteList.MatchingCustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret",
+		Location: &model.CustomerLocation{Latitude: 53.339111, Longitude: -6.257611, Distance: 0.5, Unit: "K"}}}
+	inviteList.UnMatchingCustomerIds = []model.CustomerDetails{{UserId: 2, Name: "Michael Barret"}}
+	inviteList.RejectedCustomerIds = []model.RejectedCustomer{{UserId: 3, Name: "Ian Barret", Latitude: "north", Reason: "invalid latitude"}}
+	geofence, err := geo.ReadGeofence([]byte(`{"type": "Polygon", "coordinates": [[[-6.6, 53.2], [-6.0, 53.2], [-6.0, 53.6], [-6.6, 53.2]]]}`))
+	if err != nil {
+		t.Errorf("ReadGeofence() error = %v", err)
+		return
+	}
+	type feature struct {
+		Type     string `json:"type"`
+		Geometry *struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	tests := []struct {
+		name          string
+		layout        GeoJsonLayout
+		wantRoles     []string
+		wantGeometry  []string
+		wantCustomers []map[string]interface{}
+	}{
+		{
+			name: "Encode customers with home location and radius",
+			layout: GeoJsonLayout{
+				Centres: []model.Venue{{Name: "home", Latitude: 53.339428, Longitude: -6.257664, Radius: 100}},
+				Unit:    geo.Kilometers,
+			},
+			wantRoles:    []string{"home", "radius", "customer", "customer", "customer"},
+			wantGeometry: []string{"Point", "Polygon", "Point", "", ""},
+			wantCustomers: []map[string]interface{}{
+				{"role": "customer", "user_id": 1.0, "name": "Thomas Barret", "status": "invited", "distance": 0.5, "unit": "K"},
+				{"role": "customer", "user_id": 2.0, "name": "Michael Barret", "status": "excluded"},
+				{"role": "customer", "user_id": 3.0, "name": "Ian Barret", "status": "rejected", "reason": "invalid latitude"},
+			},
+		},
+		{
+			name: "Encode customers with geofence",
+			layout: GeoJsonLayout{
+				Centres:  []model.Venue{{Name: "home", Latitude: 53.339428, Longitude: -6.257664}},
+				Unit:     geo.Kilometers,
+				Geofence: geofence,
+			},
+			wantRoles:    []string{"home", "geofence", "customer", "customer", "customer"},
+			wantGeometry: []string{"Point", "Polygon", "Point", "", ""},
+			wantCustomers: []map[string]interface{}{
+				{"role": "customer", "user_id": 1.0, "name": "Thomas Barret", "status": "invited", "distance": 0.5, "unit": "K"},
+				{"role": "customer", "user_id": 2.0, "name": "Michael Barret", "status": "excluded"},
+				{"role": "customer", "user_id": 3.0, "name": "Ian Barret", "status": "rejected", "reason": "invalid latitude"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeCustomerGeoJson(&inviteList, tt.layout)
+			if err != nil {
+				t.Errorf("EncodeCustomerGeoJson() error = %v", err)
+				return
+			}
+			var collection struct {
+				Type     string    `json:"type"`
+				Features []feature `json:"features"`
+			}
+			if err = json.Unmarshal(data, &collection); err != nil {
+				t.Errorf("EncodeCustomerGeoJson() invalid json = %v", err)
+				return
+			}
+			if collection.Type != "FeatureCollection" || len(collection.Features) != len(tt.wantRoles) {
+				t.Errorf("EncodeCustomerGeoJson() got = %s, want FeatureCollection with %v features", string(data), len(tt.wantRoles))
+				return
+			}
+			customers := make([]map[string]interface{}, 0)
+			for i, f := range collection.Features {
+				if f.Properties["role"] != tt.wantRoles[i] {
+					t.Errorf("EncodeCustomerGeoJson() feature %v role = %v, want %v", i, f.Properties["role"], tt.wantRoles[i])
+				}
+				geometry := ""
+				if f.Geometry != nil {
+					geometry = f.Geometry.Type
+				}
+				if geometry != tt.wantGeometry[i] {
+					t.Errorf("EncodeCustomerGeoJson() feature %v geometry = %v, want %v", i, geometry, tt.wantGeometry[i])
+				}
+				if f.Properties["role"] == "customer" {
+					customers = append(customers, f.Properties)
+				}
+			}
+			if !reflect.DeepEqual(customers, tt.wantCustomers) {
+				t.Errorf("EncodeCustomerGeoJson() customers = %v, want %v", customers, tt.wantCustomers)
+			}
+			if string(collection.Features[0].Geometry.Coordinates) != "[-6.257664,53.339428]" {
+				t.Errorf("EncodeCustomerGeoJson() home coordinates = %s, want [longitude, latitude]", collection.Features[0].Geometry.Coordinates)
+			}
+		})
+	}
+}
+
+func TestEncodeCustomerInvite_geoJson(t *testing.T) {
+	inviteList := *model.NewInviteList()
+	inviteList.Venues = []model.VenueInviteList{
+		{Venue: "Dublin", CustomerIds: []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}},
+	}
+	want := `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":null,"properties":{"name":"Thomas Barret","role":"customer","status":"invited","user_id":1,"venue":"Dublin"}}]}`
+	gotData, err := EncodeCustomerInvite(inviteList, GeoJsonEncoding)
+	if err != nil {
+		t.Errorf("EncodeCustomerInvite() error = %v", err)
+		return
+	}
+	if string(gotData) != want {
+		t.Errorf("EncodeCustomerInvite() gotData = %s, want %s", string(gotData), want)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package io
+
+import (
+	"encoding/json"
+	"github.com/hellgate75/go-invite-customers/model"
+)
+
+const (
+	InvitedDecision  string = "invited"
+	ExcludedDecision string = "excluded"
+	RejectedDecision string = "rejected"
+)
+
+// Describe a single invitation decision, written as one line by the ndjson encoding
+type Decision struct {
+	Decision string                  `json:"decision"`
+	UserId   int64                   `json:"user_id"`
+	Name     string                  `json:"name"`
+	Venue    string                  `json:"venue,omitempty"`
+	Location *model.CustomerLocation `json:"location,omitempty"`
+	Reason   string                  `json:"reason,omitempty"`
+}
+
+//  Encode the decision as a JSON line, terminated by the new line character, reporting any
+//  error arisen during the encoding
+//
+//  Decision/
+//  The io.Decision data type instance to be converted
+//
+//  The output are the byte array and the error, if occurred during the encoding operations.
+func EncodeDecision(decision Decision) (data []byte, err error) {
+	data, err = json.Marshal(&decision)
+	if err != nil {
+		return data, err
+	}
+	return append(data, '\n'), err
+}
+
+func ndjsonEncode(invited []model.CustomerDetails, venues []model.VenueInviteList, excluded []model.CustomerDetails, rejected []model.RejectedCustomer) (out []byte, err error) {
+	out = make([]byte, 0)
+	decisions := make([]Decision, 0)
+	if len(venues) > 0 {
+		for _, group := range venues {
+			for _, c := range group.CustomerIds {
+				decisions = append(decisions, Decision{InvitedDecision, c.UserId, c.Name, group.Venue, c.Location, ""})
+			}
+		}
+	} else {
+		for _, c := range invited {
+			decisions = append(decisions, Decision{InvitedDecision, c.UserId, c.Name, "", c.Location, ""})
+		}
+	}
+	for _, c := range excluded {
+		decisions = append(decisions, Decision{ExcludedDecision, c.UserId, c.Name, "", c.Location, ""})
+	}
+	for _, c := range rejected {
+		decisions = append(decisions, Decision{RejectedDecision, c.UserId, c.Name, "", nil, c.Reason})
+	}
+	for _, decision := range decisions {
+		line, errE := EncodeDecision(decision)
+		if errE != nil {
+			return out, errE
+		}
+		out = append(out, line...)
+	}
+	return out, err
+}
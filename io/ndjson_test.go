@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package io
+
+import (
+	"github.com/hellgate75/go-invite-customers/model"
+	"testing"
+)
+
+func TestEncodeDecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision Decision
+		wantData string
+	}{
+		{
+			name:     "Encode invited decision",
+			decision: Decision{Decision: InvitedDecision, UserId: 1, Name: "Thomas Barret"},
+			wantData: "{\"decision\":\"invited\",\"user_id\":1,\"name\":\"Thomas Barret\"}\n",
+		},
+		{
+			name:     "Encode rejected decision",
+			decision: Decision{Decision: RejectedDecision, UserId: 2, Name: "Michael Barret", Reason: "Invalid coordinates"},
+			wantData: "{\"decision\":\"rejected\",\"user_id\":2,\"name\":\"Michael Barret\",\"reason\":\"Invalid coordinates\"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotData, err := EncodeDecision(tt.decision)
+			if err != nil {
+				t.Errorf("EncodeDecision() error = %v", err)
+				return
+			}
+			if string(gotData) != tt.wantData {
+				t.Errorf("EncodeDecision() gotData = %v, want %v", string(gotData), tt.wantData)
+			}
+		})
+	}
+}
+
+func TestEncodeCustomerDetailedInvite_ndjson(t *testing.T) {
+	inviteList := *model.NewCompleteInviteList()
+	inviteList.MatchingCustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}
+	inviteList.UnMatchingCustomerIds = []model.CustomerDetails{{UserId: 2, Name: "Michael Barret"}}
+	inviteList.RejectedCustomerIds = []model.RejectedCustomer{{UserId: 3, Name: "Ian Barret", Reason: "invalid latitude"}}
+	want := "{\"decision\":\"invited\",\"user_id\":1,\"name\":\"Thomas Barret\"}\n" +
+		"{\"decision\":\"excluded\",\"user_id\":2,\"name\":\"Michael Barret\"}\n" +
+		"{\"decision\":\"rejected\",\"user_id\":3,\"name\":\"Ian Barret\",\"reason\":\"invalid latitude\"}\n"
+	gotData, err := EncodeCustomerDetailedInvite(inviteList, NdjsonEncoding)
+	if err != nil {
+		t.Errorf("EncodeCustomerDetailedInvite() error = %v", err)
+		return
+	}
+	if string(gotData) != want {
+		t.Errorf("EncodeCustomerDetailedInvite() gotData = %v, want %v", string(gotData), want)
+	}
+}
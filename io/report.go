@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package io
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/hellgate75/go-invite-customers/model"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// Describe the tabular report of the customers, shared by the csv, markdown and html encodings
+type reportTable struct {
+	header   []string
+	rows     [][]string
+	invited  int
+	excluded int
+	rejected int
+}
+
+// Build the report table of the invited customers, grouped by venue when venues are available,
+// followed by the excluded and the rejected ones. The status column is reported for the detailed
+// output only, the venue column when venues are available, the location columns when any
+// customer has it and the reason column when any customer is rejected.
+func newReportTable(invited []model.CustomerDetails, venues []model.VenueInviteList, excluded []model.CustomerDetails, rejected []model.RejectedCustomer, detailed bool) reportTable {
+	type entry struct {
+		customer model.CustomerDetails
+		status   string
+		venue    string
+		rejected *model.RejectedCustomer
+	}
+	entries := make([]entry, 0)
+	if len(venues) > 0 {
+		for _, group := range venues {
+			for _, c := range group.CustomerIds {
+				entries = append(entries, entry{c, "invited", group.Venue, nil})
+			}
+		}
+	} else {
+		for _, c := range invited {
+			entries = append(entries, entry{c, "invited", "", nil})
+		}
+	}
+	table := reportTable{invited: len(entries), excluded: len(excluded), rejected: len(rejected)}
+	for _, c := range excluded {
+		entries = append(entries, entry{c, "excluded", "", nil})
+	}
+	located := len(rejected) > 0
+	for _, e := range entries {
+		located = located || e.customer.Location != nil
+	}
+	for i := range rejected {
+		c := &rejected[i]
+		entries = append(entries, entry{model.CustomerDetails{UserId: c.UserId, Name: c.Name}, "rejected", "", c})
+	}
+	table.header = []string{"user_id", "name"}
+	if detailed {
+		table.header = append(table.header, "status")
+	}
+	if len(venues) > 0 {
+		table.header = append(table.header, "venue")
+	}
+	if located {
+		table.header = append(table.header, "latitude", "longitude", "distance", "unit")
+	}
+	if len(rejected) > 0 {
+		table.header = append(table.header, "reason")
+	}
+	table.rows = make([][]string, 0)
+	for _, e := range entries {
+		row := []string{strconv.FormatInt(e.customer.UserId, 10), e.customer.Name}
+		if detailed {
+			row = append(row, e.status)
+		}
+		if len(venues) > 0 {
+			row = append(row, e.venue)
+		}
+		if located {
+			if r := e.rejected; r != nil {
+				// Rejected customers report the input coordinates
+				row = append(row, r.Latitude, r.Longitude, "", "")
+			} else if l := e.customer.Location; l != nil {
+				row = append(row, strconv.FormatFloat(l.Latitude, 'f', -1, 64),
+					strconv.FormatFloat(l.Longitude, 'f', -1, 64), fmt.Sprintf("%.3f", l.Distance), l.Unit)
+			} else {
+				row = append(row, "", "", "", "")
+			}
+		}
+		if len(rejected) > 0 {
+			reason := ""
+			if e.rejected != nil {
+				reason = e.rejected.Reason
+			}
+			row = append(row, reason)
+		}
+		table.rows = append(table.rows, row)
+	}
+	return table
+}
+
+func csvEncodeTable(table reportTable) (out []byte, err error) {
+	buff := bytes.NewBuffer([]byte{})
+	w := csv.NewWriter(buff)
+	if err = w.Write(table.header); err == nil {
+		err = w.WriteAll(table.rows)
+	}
+	return buff.Bytes(), err
+}
+
+func markdownEncodeTable(table reportTable) (out []byte, err error) {
+	escape := func(cells []string) string {
+		escaped := make([]string, 0)
+		for _, cell := range cells {
+			escaped = append(escaped, strings.Replace(cell, "|", "\\|", -1))
+		}
+		return "| " + strings.Join(escaped, " | ") + " |\n"
+	}
+	separator := make([]string, 0)
+	for range table.header {
+		separator = append(separator, "---")
+	}
+	text := escape(table.header) + escape(separator)
+	for _, row := range table.rows {
+		text += escape(row)
+	}
+	return []byte(text), err
+}
+
+func htmlEncodeTable(table reportTable, detailed bool) (out []byte, err error) {
+	buff := bytes.NewBuffer([]byte{})
+	buff.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Invite Summary</title>\n")
+	buff.WriteString("<style>table{border-collapse:collapse}th,td{border:1px solid #999;padding:4px 8px;text-align:left}</style>\n")
+	buff.WriteString("</head>\n<body>\n<h1>Invite Summary</h1>\n")
+	if detailed {
+		buff.WriteString(fmt.Sprintf("<p>Invited: %v, Excluded: %v, Rejected: %v</p>\n", table.invited, table.excluded, table.rejected))
+	} else {
+		buff.WriteString(fmt.Sprintf("<p>Invited: %v</p>\n", table.invited))
+	}
+	buff.WriteString("<table>\n<thead>\n<tr>")
+	for _, cell := range table.header {
+		buff.WriteString("<th>" + html.EscapeString(cell) + "</th>")
+	}
+	buff.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range table.rows {
+		buff.WriteString("<tr>")
+		for _, cell := range row {
+			buff.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+		}
+		buff.WriteString("</tr>\n")
+	}
+	buff.WriteString("</tbody>\n</table>\n</body>\n</html>\n")
+	return buff.Bytes(), err
+}
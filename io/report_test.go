@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package io
+
+import (
+	"github.com/hellgate75/go-invite-customers/model"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_newReportTable(t *testing.T) {
+	type args struct {
+		invited  []model.CustomerDetails
+		venues   []model.VenueInviteList
+		excluded []model.CustomerDetails
+		rejected []model.RejectedCustomer
+		detailed bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want reportTable
+	}{
+		{
+			name: "Build simple report table",
+			args: args{
+				invited: []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}},
+			},
+			want: reportTable{
+				header:  []string{"user_id", "name"},
+				rows:    [][]string{{"1", "Thomas Barret"}},
+				invited: 1,
+			},
+		},
+		{
+			name: "Build detailed report table with location",
+			args: args{
+				invited: []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret",
+					Location: &model.CustomerLocation{Latitude: 53.339111, Longitude: -6.257611, Distance: 0.5, Unit: "K"}}},
+				excluded: []model.CustomerDetails{{UserId: 2, Name: "Michael Barret"}},
+				detailed: true,
+			},
+			want: reportTable{
+				header: []string{"user_id", "name", "status", "latitude", "longitude", "distance", "unit"},
+				rows: [][]string{
+					{"1", "Thomas Barret", "invited", "53.339111", "-6.257611", "0.500", "K"},
+					{"2", "Michael Barret", "excluded", "", "", "", ""},
+				},
+				invited:  1,
+				excluded: 1,
+			},
+		},
+		{
+			name: "Build detailed report table with rejections",
+			args: args{
+				invited:  []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}},
+				rejected: []model.RejectedCustomer{{UserId: 4, Name: "Ian Barret", Latitude: "north", Longitude: "-6.2", Reason: "invalid latitude"}},
+				detailed: true,
+			},
+			want: reportTable{
+				header: []string{"user_id", "name", "status", "latitude", "longitude", "distance", "unit", "reason"},
+				rows: [][]string{
+					{"1", "Thomas Barret", "invited", "", "", "", "", ""},
+					{"4", "Ian Barret", "rejected", "north", "-6.2", "", "", "invalid latitude"},
+				},
+				invited:  1,
+				rejected: 1,
+			},
+		},
+		{
+			name: "Build report table with venues",
+			args: args{
+				venues: []model.VenueInviteList{
+					{Venue: "Dublin", CustomerIds: []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}},
+					{Venue: "Cork", CustomerIds: []model.CustomerDetails{{UserId: 3, Name: "John Barret"}}},
+				},
+			},
+			want: reportTable{
+				header:  []string{"user_id", "name", "venue"},
+				rows:    [][]string{{"1", "Thomas Barret", "Dublin"}, {"3", "John Barret", "Cork"}},
+				invited: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newReportTable(tt.args.invited, tt.args.venues, tt.args.excluded, tt.args.rejected, tt.args.detailed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newReportTable() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeCustomerInvite_reports(t *testing.T) {
+	inviteList := *model.NewInviteList()
+	inviteList.CustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Barret, Thomas"}, {UserId: 2, Name: "A|B <C>"}}
+	tests := []struct {
+		name     string
+		enc      Encoding
+		wantData string
+	}{
+		{
+			name:     "Encode a valid model.InviteList to CSV format",
+			enc:      CsvEncoding,
+			wantData: "user_id,name\n1,\"Barret, Thomas\"\n2,A|B <C>\n",
+		},
+		{
+			name:     "Encode a valid model.InviteList to Markdown format",
+			enc:      MarkdownEncoding,
+			wantData: "| user_id | name |\n| --- | --- |\n| 1 | Barret, Thomas |\n| 2 | A\\|B <C> |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotData, err := EncodeCustomerInvite(inviteList, tt.enc)
+			if err != nil {
+				t.Errorf("EncodeCustomerInvite() error = %v", err)
+				return
+			}
+			if string(gotData) != tt.wantData {
+				t.Errorf("EncodeCustomerInvite() gotData = %v, want %v", string(gotData), tt.wantData)
+			}
+		})
+	}
+	gotData, err := EncodeCustomerInvite(inviteList, HtmlEncoding)
+	if err != nil {
+		t.Errorf("EncodeCustomerInvite() error = %v", err)
+		return
+	}
+	for _, want := range []string{"<!DOCTYPE html>", "<p>Invited: 2</p>", "<th>user_id</th><th>name</th>",
+		"<td>2</td><td>A|B &lt;C&gt;</td>", "</html>"} {
+		if !strings.Contains(string(gotData), want) {
+			t.Errorf("EncodeCustomerInvite() gotData = %v, want to contain %v", string(gotData), want)
+		}
+	}
+}
+
+func TestEncodeCustomerDetailedInvite_reports(t *testing.T) {
+	inviteList := *model.NewCompleteInviteList()
+	inviteList.MatchingCustomerIds = []model.CustomerDetails{{UserId: 1, Name: "Thomas Barret"}}
+	inviteList.UnMatchingCustomerIds = []model.CustomerDetails{{UserId: 2, Name: "Michael Barret"}}
+	tests := []struct {
+		name     string
+		enc      Encoding
+		wantData string
+	}{
+		{
+			name:     "Encode a valid model.CompleteInviteList to CSV format",
+			enc:      CsvEncoding,
+			wantData: "user_id,name,status\n1,Thomas Barret,invited\n2,Michael Barret,excluded\n",
+		},
+		{
+			name: "Encode a valid model.CompleteInviteList to Markdown format",
+			enc:  MarkdownEncoding,
+			wantData: "| user_id | name | status |\n| --- | --- | --- |\n" +
+				"| 1 | Thomas Barret | invited |\n| 2 | Michael Barret | excluded |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotData, err := EncodeCustomerDetailedInvite(inviteList, tt.enc)
+			if err != nil {
+				t.Errorf("EncodeCustomerDetailedInvite() error = %v", err)
+				return
+			}
+			if string(gotData) != tt.wantData {
+				t.Errorf("EncodeCustomerDetailedInvite() gotData = %v, want %v", string(gotData), tt.wantData)
+			}
+		})
+	}
+	gotData, err := EncodeCustomerDetailedInvite(inviteList, HtmlEncoding)
+	if err != nil {
+		t.Errorf("EncodeCustomerDetailedInvite() error = %v", err)
+		return
+	}
+	for _, want := range []string{"<p>Invited: 1, Excluded: 1, Rejected: 0</p>", "<td>2</td><td>Michael Barret</td><td>excluded</td>"} {
+		if !strings.Contains(string(gotData), want) {
+			t.Errorf("EncodeCustomerDetailedInvite() gotData = %v, want to contain %v", string(gotData), want)
+		}
+	}
+}
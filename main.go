@@ -9,12 +9,21 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"github.com/hellgate75/go-invite-customers/geo"
 	"github.com/hellgate75/go-invite-customers/invite"
 	"github.com/hellgate75/go-invite-customers/io"
+	"github.com/hellgate75/go-invite-customers/model"
+	"github.com/hellgate75/go-invite-customers/server"
+	io2 "io"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 var flagSet *flag.FlagSet
@@ -29,11 +38,27 @@ var usePerLineInput bool = true
 var silentOutput bool = false
 var outputEncoding string = "text"
 var useDetailedOutput bool = false
+var workerPoolSize int = 0
+var sortOrder string = "none"
+var distanceAlgorithm string = "cosines"
+var geofenceFile string
+var venuesFile string
+var csvColumns string
+var csvHeader bool = true
+var outputFile string
+var errorsFile string
+var duplicatePolicy string = "first"
+var serveMode bool = false
+var listenAddress string = ":8080"
+var idleTimeout time.Duration = 0
+var endMessage string
+var maxDatagramSize int = invite.DefaultMaxDatagramSize
 
 func printUsage(message string, exitCode int) {
 	fmt.Println("go-invite-customers -[param0]=value0 ...  -[paramN]=valueN")
-	if len(message) == 0 {
-		fmt.Printf("Error: %s", message)
+	fmt.Println("go-invite-customers serve -listen=address -[param0]=value0 ...  -[paramN]=valueN")
+	if len(message) > 0 {
+		fmt.Printf("Error: %s\n", message)
 	}
 	fmt.Println("Parameters:")
 	flagSet.PrintDefaults()
@@ -42,19 +67,79 @@ func printUsage(message string, exitCode int) {
 	}
 }
 
+// Encoding of a file, from its extension, json is used by default
+func fileEncoding(file string) io.Encoding {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(file), "."))
+	if ext == "yml" {
+		ext = "yaml"
+	}
+	switch enc, _ := io.ToEncoding(ext); enc {
+	case io.JsonEncoding, io.YamlEncoding, io.XmlEncoding:
+		return enc
+	}
+	return io.JsonEncoding
+}
+
+// Write the JSON report of the scan errors to the given file or url
+func writeErrorReport(destination string, errs []error) error {
+	data, err := io.EncodeErrorReport(model.NewErrorReport(errs), io.JsonEncoding)
+	if err != nil {
+		return err
+	}
+	w, err := invite.OpenOutputStream(destination, io.ContentType(io.JsonEncoding))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	if errC := w.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+// Serve the invitation scans over http, with the given default input data, until the server fails
+func serve(defaults invite.InputData) {
+	if !silentOutput {
+		fmt.Printf("Serving invitation scans at http://%s%s ....\n", listenAddress, server.InvitePath)
+	}
+	if err := http.ListenAndServe(listenAddress, server.NewInviteHandler(defaults)); err != nil {
+		fmt.Printf("Error serving invitation scans: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 func init() {
 	flagSet = flag.NewFlagSet("go-invite-customers", flag.ContinueOnError)
-	flagSet.StringVar(&fileOrStream, "input", "", "Given file, url or pipe that contains data")
+	flagSet.StringVar(&fileOrStream, "input", "", "Given file, url (udp://host:port, tcp://host:port, udp-listen://[host]:port, tcp-listen://[host]:port, [http, https]://host[:port]/.., [ftp, sftp]://[user[:password]@]host[:port]/path) or pipe that contains data")
 	flagSet.Float64Var(&homeLatitude, "latitude", homeLatitude, "Base latitude degrees in float number [W is negative]")
 	flagSet.Float64Var(&homeLongitude, "longitude", homeLongitude, "Base longitude degrees in float number [S is negative]")
 	flagSet.Float64Var(&distance, "distance", distance, "Max distance from base coordinate")
-	flagSet.StringVar(&measureUnit, "unit", "K", "Measure Unit for distance [K is for Kilometers, M is for Miles and N is for Nautical Miles]")
+	flagSet.StringVar(&measureUnit, "unit", "K", "Measure Unit for distance [K is for Kilometers, M is for Miles, N is for Nautical Miles, MT is for Metres, FT is for Feet and YD is for Yards]")
 	flagSet.StringVar(&inputEncoding, "in-enc", "json", fmt.Sprintf("Input encoding format: %v", io.InputEncoding))
+	flagSet.StringVar(&csvColumns, "csv-columns", "", "Csv and tsv input columns, as header names or zero-based indexes (e.g.: user_id=id,name=full_name,latitude=lat,longitude=lng)")
+	flagSet.BoolVar(&csvHeader, "csv-header", true, "Csv and tsv input starts with a header line")
 	flagSet.StringVar(&outputEncoding, "out-enc", "text", fmt.Sprintf("Output encoding format: %v", io.OutputEncoding))
+	flagSet.StringVar(&outputFile, "output", "", "Given file or url (udp://host:port, tcp://host:port, [http, https]://host[:port]/..) receiving the output data, instead of the standard output")
+	flagSet.StringVar(&errorsFile, "errors-out", "", "Given file or url receiving the JSON report of the processing errors, instead of printing them")
 	flagSet.BoolVar(&usePerLineInput, "per-line-input", true, "Use one read line in input for parsing the data, instead of reading the list")
 	flagSet.BoolVar(&silentOutput, "silent", false, "Execute silent output")
-	flagSet.BoolVar(&useDetailedOutput, "detailed", false, "Create Output for invited and excluded, instead of only invited customers")
-	err := flagSet.Parse(os.Args[1:])
+	flagSet.BoolVar(&useDetailedOutput, "detailed", false, "Create Output for invited and excluded, with coordinates and distance, instead of only invited customers")
+	flagSet.StringVar(&distanceAlgorithm, "algorithm", "cosines", fmt.Sprintf("Distance calculation algorithm: %v", geo.Algorithms))
+	flagSet.StringVar(&geofenceFile, "geofence", "", "GeoJSON file with the Polygon or MultiPolygon of the invitation area, used instead of the distance")
+	flagSet.StringVar(&venuesFile, "venues", "", "Json, yaml or xml file with the event venues (name, latitude, longitude and radius), used instead of the base coordinates")
+	flagSet.StringVar(&sortOrder, "sort", "none", fmt.Sprintf("Output customers sort order: %v", model.SortOrders))
+	flagSet.StringVar(&duplicatePolicy, "duplicates", "first", fmt.Sprintf("Policy of the customers with the same user id, first or last record wins, or all rejected, last and error hold the records until the end of the input: %v", model.DuplicatePolicies))
+	flagSet.IntVar(&workerPoolSize, "workers", 0, "Number of concurrent customer evaluation workers [0 is for number of CPUs]")
+	flagSet.DurationVar(&idleTimeout, "idle-timeout", idleTimeout, "Time without any received record after which the udp, udp-listen and tcp-listen inputs end [0 is for no timeout]")
+	flagSet.StringVar(&endMessage, "end-message", "", "Line ending the udp, udp-listen and tcp-listen inputs when received from any client")
+	flagSet.IntVar(&maxDatagramSize, "datagram-size", maxDatagramSize, "Max size of the udp and udp-listen input datagrams, the larger ones are reported and discarded")
+	flagSet.StringVar(&listenAddress, "listen", listenAddress, "Address of the http server, in serve mode, where the parameters are the defaults of the POST /invite requests")
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "serve" {
+		serveMode = true
+		args = args[1:]
+	}
+	err := flagSet.Parse(args)
 	if err != nil {
 		printUsage(err.Error(), 1)
 	}
@@ -64,16 +149,15 @@ func main() {
 	if distance <= 0 || measureUnit == "" {
 		printUsage("Distance cannot be zero or less and unit cannot be empty", 2)
 	}
-	measureUnit = strings.ToUpper(measureUnit)
-	if measureUnit != "K" && measureUnit != "M" && measureUnit != "N" {
-		printUsage("Distance Measure Unit can have only on of 'K', 'M' or 'N' values", 2)
+	unit, err := geo.ParseUnit(measureUnit)
+	if err != nil {
+		printUsage(err.Error(), 2)
 	}
 	fileOrStream = strings.TrimSpace(fileOrStream)
-	if "" == fileOrStream {
+	if "" == fileOrStream && !serveMode {
 		printUsage("File, stream or pipe reference cannot be empty", 2)
 	}
 	var inEnc, outEnc io.Encoding
-	var err error
 	if inEnc, err = io.ToEncoding(inputEncoding); err != nil {
 		printUsage(fmt.Sprintf("Error converting input encoding from string: %s", inputEncoding), 2)
 
@@ -82,45 +166,169 @@ func main() {
 		printUsage(fmt.Sprintf("Error converting output encoding from string: %s", outputEncoding), 2)
 
 	}
+	var csvMapping io.CsvMapping
+	if csvMapping, err = io.ParseCsvMapping(csvColumns); err != nil {
+		printUsage(err.Error(), 2)
+	}
+	csvMapping.NoHeader = !csvHeader
+	if _, err = io.NewCsvDecoder(io.CsvEncoding, csvMapping); err != nil {
+		printUsage(err.Error(), 2)
+	}
+	if outEnc == io.GeoJsonEncoding {
+		// Map points need the customers location, reported by the detailed output
+		useDetailedOutput = true
+	}
+	var algorithm geo.Algorithm
+	if algorithm, err = geo.ToAlgorithm(distanceAlgorithm); err != nil {
+		printUsage(fmt.Sprintf("Error converting distance algorithm from string: %s", distanceAlgorithm), 2)
+	}
+	var geofence *geo.Geofence
+	if geofenceFile = strings.TrimSpace(geofenceFile); geofenceFile != "" {
+		data, err := ioutil.ReadFile(geofenceFile)
+		if err == nil {
+			geofence, err = geo.ReadGeofence(data)
+		}
+		if err != nil {
+			printUsage(fmt.Sprintf("Error reading geofence file %s: %v", geofenceFile, err), 2)
+		}
+	}
+	var venues []model.Venue
+	if venuesFile = strings.TrimSpace(venuesFile); venuesFile != "" {
+		data, err := ioutil.ReadFile(venuesFile)
+		var list model.VenueList
+		if err == nil {
+			list, err = io.ReadVenueList(data, fileEncoding(venuesFile))
+		}
+		if err == nil && len(list.List) == 0 {
+			err = errors.New("no venue defined")
+		}
+		if err != nil {
+			printUsage(fmt.Sprintf("Error reading venues file %s: %v", venuesFile, err), 2)
+		}
+		venues = list.List
+	}
+	var order model.SortOrder
+	if order, err = model.ToSortOrder(sortOrder); err != nil {
+		printUsage(fmt.Sprintf("Error converting sort order from string: %s", sortOrder), 2)
+	}
+	if order != model.NoSort && outEnc == io.NdjsonEncoding {
+		// Decisions are streamed as they are made, in evaluation order
+		printUsage("Sort order cannot be used with the ndjson output encoding", 2)
+	}
+	if idleTimeout < 0 {
+		printUsage("Idle timeout cannot be negative", 2)
+	}
+	if maxDatagramSize <= 0 || maxDatagramSize > invite.DefaultMaxDatagramSize {
+		printUsage(fmt.Sprintf("Max datagram size must be in the [1, %v] range", invite.DefaultMaxDatagramSize), 2)
+	}
+	var policy model.DuplicatePolicy
+	if policy, err = model.ToDuplicatePolicy(duplicatePolicy); err != nil {
+		printUsage(fmt.Sprintf("Error converting duplicate policy from string: %s", duplicatePolicy), 2)
+	}
+	input := invite.InputData{
+		FileOrStream:      fileOrStream,
+		HomeLatitude:      homeLatitude,
+		HomeLongitude:     homeLongitude,
+		Distance:          distance,
+		MeasureUnit:       unit,
+		InputEncoding:     inEnc,
+		UsePerLineInput:   usePerLineInput,
+		UseDetailedOutput: useDetailedOutput,
+		SilentOutput:      silentOutput,
+		OutputEncoding:    outEnc,
+		WorkerPoolSize:    workerPoolSize,
+		SortOrder:         order,
+		Algorithm:         algorithm,
+		Geofence:          geofence,
+		Venues:            venues,
+		CsvMapping:        csvMapping,
+		DuplicatePolicy:   policy,
+		IdleTimeout:       idleTimeout,
+		EndMessage:        endMessage,
+		MaxDatagramSize:   maxDatagramSize,
+	}
+	if serveMode {
+		serve(input)
+		return
+	}
+	// Progress and errors messages, moved to the standard error when the output is streamed
+	// to the standard output
+	var messages io2.Writer = os.Stdout
+	var output io2.Writer = os.Stdout
+	var destination io2.WriteCloser
+	if outputFile = strings.TrimSpace(outputFile); outputFile != "" {
+		if destination, err = invite.OpenOutputStream(outputFile, io.ContentType(outEnc)); err != nil {
+			printUsage(fmt.Sprintf("Error opening output %s: %v", outputFile, err), 2)
+		}
+		output = destination
+	} else if outEnc == io.NdjsonEncoding {
+		messages = os.Stderr
+	}
+	var sink *invite.NdjsonSink
+	if outEnc == io.NdjsonEncoding {
+		sink = invite.NewNdjsonSink(output, useDetailedOutput)
+	}
 	if !silentOutput {
-		fmt.Println("Calculating customers within given distance from the base coordinates....")
-	}
-	out, errs := invite.ExecuteInviteScan(invite.InputData{
-		fileOrStream,
-		homeLatitude,
-		homeLongitude,
-		distance,
-		measureUnit,
-		inEnc,
-		usePerLineInput,
-		useDetailedOutput,
-		silentOutput,
-		outEnc,
-	})
-	if len(errs) > 0 {
+		if strings.HasPrefix(fileOrStream, "tcp-listen://") || strings.HasPrefix(fileOrStream, "udp-listen://") {
+			fmt.Fprintf(messages, "Listening for customers at %s....\n", fileOrStream)
+		}
+		fmt.Fprintln(messages, "Calculating customers within given distance from the base coordinates....")
+	}
+	if sink != nil {
+		// Decisions are written as they are made
+		input.Sink = sink
+	}
+	out, errs := invite.ExecuteInviteScan(input)
+	if out.Duplicates > 0 && !silentOutput {
+		fmt.Fprintf(messages, "%v duplicate customer records found, applied policy: %s\n", out.Duplicates, policy)
+	}
+	// The duplicates dropped by the first and last wins policies are reported with the errors
+	reported := append(errs, out.DroppedDuplicates...)
+	if errorsFile = strings.TrimSpace(errorsFile); errorsFile != "" {
+		if err = writeErrorReport(errorsFile, reported); err != nil {
+			fmt.Fprintf(messages, "Error writing errors report to %s: %v\n", errorsFile, err)
+		} else if len(reported) > 0 && !silentOutput {
+			fmt.Fprintf(messages, "%v processing errors and %v dropped duplicates reported to %s\n", len(errs), len(out.DroppedDuplicates), errorsFile)
+		}
+	} else if len(reported) > 0 {
 		if silentOutput {
-			fmt.Println("Processing errors occurred in evaluation, please run without silent option for details")
+			fmt.Fprintln(messages, "Processing errors or dropped duplicates occurred in evaluation, please run without silent option for details")
 		} else {
-			fmt.Println("Processing errors:")
-			for _, err := range errs {
-				fmt.Println(err.Error())
+			fmt.Fprintf(messages, "Processing errors (%v) and dropped duplicates (%v):\n", len(errs), len(out.DroppedDuplicates))
+			for _, err := range reported {
+				fmt.Fprintln(messages, err.Error())
 			}
 		}
 	}
 	var data []byte
-	if out.IsComplete {
-		data, err = io.EncodeCustomerDetailedInvite(*out.Complete, outEnc)
+	// Output writing error, of the streaming sink or of the encoded data
+	var errW error
+	if sink != nil {
+		errW = sink.Err()
 	} else {
-		data, err = io.EncodeCustomerInvite(*out.Simple, outEnc)
+		data, err = invite.EncodeOutputData(out, input)
+		if err != nil {
+			if silentOutput {
+				fmt.Fprintln(messages, "Error converting output, please run without silent option for details")
+			} else {
+				fmt.Fprintf(messages, "Error converting output: %v\n", err)
+			}
+
+		} else {
+			_, errW = fmt.Fprintln(output, string(data))
+		}
 	}
-	if err != nil {
+	if destination != nil {
+		// Completing the output, e.g. receiving the http response
+		if errC := destination.Close(); errW == nil {
+			errW = errC
+		}
+	}
+	if errW != nil {
 		if silentOutput {
-			fmt.Println("Error converting output, please run without silent option for details")
+			fmt.Fprintln(messages, "Error writing output, please run without silent option for details")
 		} else {
-			fmt.Printf("Error converting output: %v\n", err)
+			fmt.Fprintf(messages, "Error writing output: %v\n", errW)
 		}
-
-	} else {
-		fmt.Println(string(data))
 	}
 }
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Coordinate axis, with its valid range and hemisphere letters
+type coordinateAxis struct {
+	field    string
+	limit    float64
+	positive string
+	negative string
+}
+
+var (
+	latitudeAxis  = coordinateAxis{field: "latitude", limit: 90, positive: "N", negative: "S"}
+	longitudeAxis = coordinateAxis{field: "longitude", limit: 180, positive: "E", negative: "W"}
+)
+
+// Degrees, minutes, seconds notation (e.g.: 53°20'21.9"N), the degrees symbol is required
+var dmsRegexp = regexp.MustCompile(`^([+-]?\d+(?:\.\d+)?)\s*°\s*(?:(\d+(?:\.\d+)?)\s*['′]\s*)?(?:(\d+(?:\.\d+)?)\s*(?:"|″|'')\s*)?([NSEWnsew])?$`)
+
+// Describe a customer office coordinate that is not a valid position
+type ValidationError struct {
+	// Coordinate field, latitude or longitude
+	Field string
+	// Raw value of the coordinate
+	Value  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Invalid %s \"%s\": %s", e.Field, e.Value, e.Reason)
+}
+
+// Parse the coordinate in decimal degrees or in degrees, minutes, seconds notation, verifying
+// it is a finite number in the axis range
+func (a coordinateAxis) parse(value string) (float64, error) {
+	text := strings.TrimSpace(value)
+	if text == "" {
+		return 0, &ValidationError{Field: a.field, Value: value, Reason: "missing value"}
+	}
+	var coordinate float64
+	if strings.Contains(text, "°") {
+		var reason string
+		if coordinate, reason = a.parseDms(text); reason != "" {
+			return 0, &ValidationError{Field: a.field, Value: value, Reason: reason}
+		}
+	} else {
+		var err error
+		if coordinate, err = strconv.ParseFloat(text, 64); err != nil {
+			var numError *strconv.NumError
+			if errors.As(err, &numError) && numError.Err == strconv.ErrRange {
+				return 0, &ValidationError{Field: a.field, Value: value, Reason: "not a finite number"}
+			}
+			return 0, &ValidationError{Field: a.field, Value: value, Reason: "not a number"}
+		}
+	}
+	if math.IsNaN(coordinate) || math.IsInf(coordinate, 0) {
+		return 0, &ValidationError{Field: a.field, Value: value, Reason: "not a finite number"}
+	}
+	if coordinate < -a.limit || coordinate > a.limit {
+		return 0, &ValidationError{Field: a.field, Value: value, Reason: fmt.Sprintf("out of range [%v, %v]", -a.limit, a.limit)}
+	}
+	return coordinate, nil
+}
+
+// Convert the degrees, minutes, seconds notation to decimal degrees, or report the reason
+// the notation is not valid
+func (a coordinateAxis) parseDms(text string) (float64, string) {
+	groups := dmsRegexp.FindStringSubmatch(text)
+	if groups == nil {
+		return 0, "not a valid degrees, minutes, seconds notation"
+	}
+	degrees, _ := strconv.ParseFloat(groups[1], 64)
+	var minutes, seconds float64
+	if groups[2] != "" {
+		minutes, _ = strconv.ParseFloat(groups[2], 64)
+	}
+	if groups[3] != "" {
+		seconds, _ = strconv.ParseFloat(groups[3], 64)
+	}
+	if minutes >= 60 || seconds >= 60 {
+		return 0, "minutes and seconds must be less than 60"
+	}
+	negative := strings.HasPrefix(groups[1], "-")
+	coordinate := math.Abs(degrees) + minutes/60 + seconds/3600
+	if hemisphere := strings.ToUpper(groups[4]); hemisphere != "" {
+		if hemisphere != a.positive && hemisphere != a.negative {
+			return 0, fmt.Sprintf("hemisphere must be %s or %s", a.positive, a.negative)
+		}
+		if strings.HasPrefix(groups[1], "-") || strings.HasPrefix(groups[1], "+") {
+			return 0, "both sign and hemisphere are given"
+		}
+		negative = hemisphere == a.negative
+	}
+	if negative {
+		coordinate = -coordinate
+	}
+	return coordinate, ""
+}
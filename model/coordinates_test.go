@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func Test_coordinateAxis_parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		axis       coordinateAxis
+		value      string
+		want       float64
+		wantReason string
+	}{
+		{
+			name:  "Test decimal latitude",
+			axis:  latitudeAxis,
+			value: " 53.339428 ",
+			want:  53.339428,
+		},
+		{
+			name:  "Test latitude range limit",
+			axis:  latitudeAxis,
+			value: "-90",
+			want:  -90,
+		},
+		{
+			name:       "Test latitude out of range",
+			axis:       latitudeAxis,
+			value:      "123",
+			wantReason: "out of range [-90, 90]",
+		},
+		{
+			name:       "Test longitude out of range",
+			axis:       longitudeAxis,
+			value:      "-500",
+			wantReason: "out of range [-180, 180]",
+		},
+		{
+			name:       "Test NaN latitude",
+			axis:       latitudeAxis,
+			value:      "NaN",
+			wantReason: "not a finite number",
+		},
+		{
+			name:       "Test infinite longitude",
+			axis:       longitudeAxis,
+			value:      "-Inf",
+			wantReason: "not a finite number",
+		},
+		{
+			name:       "Test overflowing longitude",
+			axis:       longitudeAxis,
+			value:      "1e400",
+			wantReason: "not a finite number",
+		},
+		{
+			name:       "Test missing latitude",
+			axis:       latitudeAxis,
+			value:      "",
+			wantReason: "missing value",
+		},
+		{
+			name:       "Test not a number latitude",
+			axis:       latitudeAxis,
+			value:      "10.22N",
+			wantReason: "not a number",
+		},
+		{
+			name:  "Test DMS latitude",
+			axis:  latitudeAxis,
+			value: `53°20'21.9"N`,
+			want:  53 + 20.0/60 + 21.9/3600,
+		},
+		{
+			name:  "Test DMS longitude in western hemisphere",
+			axis:  longitudeAxis,
+			value: `6° 15' 27.4" W`,
+			want:  -(6 + 15.0/60 + 27.4/3600),
+		},
+		{
+			name:  "Test DMS negative degrees and minutes",
+			axis:  longitudeAxis,
+			value: `-6°30'`,
+			want:  -6.5,
+		},
+		{
+			name:       "Test DMS wrong hemisphere",
+			axis:       latitudeAxis,
+			value:      `53°20'21.9"E`,
+			wantReason: "hemisphere must be N or S",
+		},
+		{
+			name:       "Test DMS sign and hemisphere",
+			axis:       latitudeAxis,
+			value:      `-53°20'S`,
+			wantReason: "both sign and hemisphere are given",
+		},
+		{
+			name:       "Test DMS minutes out of range",
+			axis:       latitudeAxis,
+			value:      `53°75'N`,
+			wantReason: "minutes and seconds must be less than 60",
+		},
+		{
+			name:       "Test DMS out of range",
+			axis:       latitudeAxis,
+			value:      `95°N`,
+			wantReason: "out of range [-90, 90]",
+		},
+		{
+			name:       "Test DMS wrong notation",
+			axis:       latitudeAxis,
+			value:      `53°20'x`,
+			wantReason: "not a valid degrees, minutes, seconds notation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.axis.parse(tt.value)
+			if tt.wantReason != "" {
+				var validationError *ValidationError
+				if !errors.As(err, &validationError) || validationError.Reason != tt.wantReason ||
+					validationError.Field != tt.axis.field || validationError.Value != tt.value {
+					t.Errorf("parse() error = %v, want reason %v", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("parse() error = %v, want nil", err)
+				return
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomerOffice_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		customer  CustomerOffice
+		wantError string
+	}{
+		{
+			name:     "Test valid coordinates",
+			customer: CustomerOffice{UserId: 1, Latitude: "53.339428", Longitude: `6°15'27.4"W`},
+		},
+		{
+			name:      "Test invalid latitude reported first",
+			customer:  CustomerOffice{UserId: 1, Latitude: "123", Longitude: "-500"},
+			wantError: "Invalid latitude \"123\": out of range [-90, 90]",
+		},
+		{
+			name:      "Test invalid longitude",
+			customer:  CustomerOffice{UserId: 1, Latitude: "53.339428", Longitude: "-500"},
+			wantError: "Invalid longitude \"-500\": out of range [-180, 180]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.customer.Validate()
+			gotError := ""
+			if err != nil {
+				gotError = err.Error()
+			}
+			if gotError != tt.wantError {
+				t.Errorf("Validate() error = %v, want %v", gotError, tt.wantError)
+			}
+			if tt.customer.IsValid() != (tt.wantError == "") {
+				t.Errorf("IsValid() = %v, want %v", tt.customer.IsValid(), tt.wantError == "")
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Policy applied to the customer records sharing the user id of another record
+type DuplicatePolicy string
+
+const (
+	KeepFirstDuplicate DuplicatePolicy = "first"
+	KeepLastDuplicate  DuplicatePolicy = "last"
+	RejectDuplicates   DuplicatePolicy = "error"
+)
+
+var DuplicatePolicies = []string{"first", "last", "error"}
+
+//  Convert text to DuplicatePolicy or return an unknown DuplicatePolicy error.
+//
+//  In/
+//  input text to be converted to DuplicatePolicy type enumeration, empty text means first wins
+//
+//  The output are the duplicate policy element and the error, if the policy text is not known.
+func ToDuplicatePolicy(in string) (policy DuplicatePolicy, err error) {
+	switch strings.ToLower(in) {
+	case "", "first":
+		policy = KeepFirstDuplicate
+	case "last":
+		policy = KeepLastDuplicate
+	case "error":
+		policy = RejectDuplicates
+	default:
+		policy = KeepFirstDuplicate
+		err = errors.New(fmt.Sprintf("Unknown duplicate policy text: %s", in))
+	}
+	return policy, err
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import "testing"
+
+func TestToDuplicatePolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         string
+		wantPolicy DuplicatePolicy
+		wantErr    bool
+	}{
+		{"Transform empty duplicate policy text", "", KeepFirstDuplicate, false},
+		{"Transform first duplicate policy text", "first", KeepFirstDuplicate, false},
+		{"Transform case sensitive last duplicate policy text", "Last", KeepLastDuplicate, false},
+		{"Transform error duplicate policy text", "error", RejectDuplicates, false},
+		{"Transform incorrect duplicate policy text", "merge", KeepFirstDuplicate, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPolicy, err := ToDuplicatePolicy(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToDuplicatePolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotPolicy != tt.wantPolicy {
+				t.Errorf("ToDuplicatePolicy() gotPolicy = %v, want %v", gotPolicy, tt.wantPolicy)
+			}
+		})
+	}
+}
+
+func TestDuplicateError_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy DuplicatePolicy
+		want   string
+	}{
+		{"Describe ignored duplicate", KeepFirstDuplicate, "Duplicate customer [7] James Barret: ignored, the first record is kept"},
+		{"Describe replaced duplicate", KeepLastDuplicate, "Duplicate customer [7] James Barret: replaced by the last record"},
+		{"Describe rejected duplicate", RejectDuplicates, "Duplicate customer [7] James Barret: rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &DuplicateError{UserId: 7, Name: "James Barret", Policy: tt.policy}
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Kind of the scan errors, as reported in the errors report
+type ErrorKind string
+
+const (
+	ParseErrorKind      ErrorKind = "parse"
+	CoordinateErrorKind ErrorKind = "coordinate"
+	StreamErrorKind     ErrorKind = "stream"
+	DuplicateErrorKind  ErrorKind = "duplicate"
+	GenericErrorKind    ErrorKind = "error"
+)
+
+// Max length of the raw input snippet reported by the parse errors
+const maxSnippetLength = 120
+
+// Describe an input record or document that cannot be decoded
+type ParseError struct {
+	// Line number in the input, starting from 1, zero if unknown
+	Line int
+	// Byte offset of the record in the input, starting from 0
+	Offset int64
+	// Raw input of the record, truncated to a reasonable length
+	Snippet string
+	Err     error
+}
+
+//  Create a parse error for the given input record.
+//
+//  Line/
+//  Line number in the input, starting from 1, zero if unknown
+//
+//  Offset/
+//  Byte offset of the record in the input
+//
+//  Raw/
+//  Raw input of the record, reported as a snippet
+//
+//  Err/
+//  Decoding error
+//
+//  The output is the parse error.
+func NewParseError(line int, offset int64, raw []byte, err error) *ParseError {
+	snippet := string(raw)
+	if len(snippet) > maxSnippetLength {
+		// Truncate at a rune start, not to split a multi-byte character
+		end := maxSnippetLength
+		for end > 0 && !utf8.RuneStart(snippet[end]) {
+			end--
+		}
+		snippet = snippet[:end] + "..."
+	}
+	return &ParseError{
+		Line:    line,
+		Offset:  offset,
+		Snippet: snippet,
+		Err:     err,
+	}
+}
+
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("Line %v: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("Parse error: %v", e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Describe a customer whose coordinates cannot be used for the evaluation
+type CoordinateError struct {
+	UserId    int64
+	Name      string
+	Latitude  string
+	Longitude string
+	Err       error
+}
+
+func (e *CoordinateError) Error() string {
+	return fmt.Sprintf("Invalid coordinates data for customer [%v] %s: %v", e.UserId, e.Name, e.Err)
+}
+
+func (e *CoordinateError) Unwrap() error {
+	return e.Err
+}
+
+// Describe a customer record whose user id is shared with another record of the input
+type DuplicateError struct {
+	UserId int64
+	Name   string
+	// Policy applied to the record
+	Policy DuplicatePolicy
+}
+
+func (e *DuplicateError) Error() string {
+	var action string
+	switch e.Policy {
+	case KeepLastDuplicate:
+		action = "replaced by the last record"
+	case RejectDuplicates:
+		action = "rejected"
+	default:
+		action = "ignored, the first record is kept"
+	}
+	return fmt.Sprintf("Duplicate customer [%v] %s: %s", e.UserId, e.Name, action)
+}
+
+// Describe an input or output stream that cannot be opened or read
+type StreamError struct {
+	// File path or url of the stream
+	Source string
+	Err    error
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("Stream %s error: %v", e.Source, e.Err)
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// Describe a scan error in the errors report
+type ErrorRecord struct {
+	Kind    ErrorKind `json:"kind" yaml:"kind" xml:"kind"`
+	Message string    `json:"message" yaml:"message" xml:"message"`
+	Line    int       `json:"line,omitempty" yaml:"line,omitempty" xml:"line,omitempty"`
+	Offset  *int64    `json:"offset,omitempty" yaml:"offset,omitempty" xml:"offset,omitempty"`
+	Snippet string    `json:"snippet,omitempty" yaml:"snippet,omitempty" xml:"snippet,omitempty"`
+	UserId  *int64    `json:"user_id,omitempty" yaml:"user_id,omitempty" xml:"user-id,omitempty"`
+	Source  string    `json:"source,omitempty" yaml:"source,omitempty" xml:"source,omitempty"`
+}
+
+// Describe the report of the errors arisen during a scan
+type ErrorReport struct {
+	Count  int           `json:"count" yaml:"count" xml:"count"`
+	Errors []ErrorRecord `json:"errors" yaml:"errors" xml:"errors"`
+}
+
+//  Convert a scan error to its report record, collecting the context of the typed errors.
+//
+//  Err/
+//  Scan error, also wrapping one of the typed errors
+//
+//  The output is the report record.
+func ToErrorRecord(err error) ErrorRecord {
+	record := ErrorRecord{
+		Kind:    GenericErrorKind,
+		Message: err.Error(),
+	}
+	var parseError *ParseError
+	var coordinateError *CoordinateError
+	var streamError *StreamError
+	var duplicateError *DuplicateError
+	if errors.As(err, &parseError) {
+		offset := parseError.Offset
+		record.Kind = ParseErrorKind
+		record.Line = parseError.Line
+		record.Offset = &offset
+		record.Snippet = parseError.Snippet
+	} else if errors.As(err, &coordinateError) {
+		userId := coordinateError.UserId
+		record.Kind = CoordinateErrorKind
+		record.UserId = &userId
+	} else if errors.As(err, &streamError) {
+		record.Kind = StreamErrorKind
+		record.Source = streamError.Source
+	} else if errors.As(err, &duplicateError) {
+		userId := duplicateError.UserId
+		record.Kind = DuplicateErrorKind
+		record.UserId = &userId
+	}
+	return record
+}
+
+//  Create the report of the scan errors
+//
+//  Errs/
+//  Scan errors, in the order they arose
+//
+//  The output is the errors report.
+func NewErrorReport(errs []error) ErrorReport {
+	report := ErrorReport{
+		Count:  len(errs),
+		Errors: make([]ErrorRecord, 0),
+	}
+	for _, err := range errs {
+		report.Errors = append(report.Errors, ToErrorRecord(err))
+	}
+	return report
+}
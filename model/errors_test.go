@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNewParseError(t *testing.T) {
+	cause := errors.New("invalid character")
+	got := NewParseError(3, 120, []byte(strings.Repeat("x", 200)), cause)
+	if got.Line != 3 || got.Offset != 120 || got.Snippet != strings.Repeat("x", 120)+"..." {
+		t.Errorf("NewParseError() = %+v, want line 3, offset 120 and truncated snippet", got)
+	}
+	if got.Error() != "Line 3: invalid character" {
+		t.Errorf("NewParseError() Error() = %v, want %v", got.Error(), "Line 3: invalid character")
+	}
+	if !errors.Is(got, cause) {
+		t.Errorf("NewParseError() does not wrap %v", cause)
+	}
+	if got := NewParseError(0, 0, nil, cause).Error(); got != "Parse error: invalid character" {
+		t.Errorf("NewParseError() Error() = %v, want %v", got, "Parse error: invalid character")
+	}
+	// The 120th byte is inside the 2 bytes character é
+	got = NewParseError(1, 0, []byte(strings.Repeat("x", 119)+strings.Repeat("é", 10)), cause)
+	if !utf8.ValidString(got.Snippet) || got.Snippet != strings.Repeat("x", 119)+"..." {
+		t.Errorf("NewParseError() Snippet = %q, want %q", got.Snippet, strings.Repeat("x", 119)+"...")
+	}
+}
+
+func TestToErrorRecord(t *testing.T) {
+	var offset int64 = 56
+	var userId int64 = 7
+	tests := []struct {
+		name string
+		err  error
+		want ErrorRecord
+	}{
+		{
+			name: "Convert parse error",
+			err:  NewParseError(2, 56, []byte("{bad"), errors.New("invalid character")),
+			want: ErrorRecord{Kind: ParseErrorKind, Message: "Line 2: invalid character", Line: 2, Offset: &offset, Snippet: "{bad"},
+		},
+		{
+			name: "Convert wrapped coordinate error",
+			err:  fmt.Errorf("evaluation: %w", &CoordinateError{UserId: 7, Name: "James Barret", Latitude: "north", Err: errors.New("invalid syntax")}),
+			want: ErrorRecord{Kind: CoordinateErrorKind, Message: "evaluation: Invalid coordinates data for customer [7] James Barret: invalid syntax", UserId: &userId},
+		},
+		{
+			name: "Convert stream error",
+			err:  &StreamError{Source: "tcp://localhost:19099", Err: errors.New("connection refused")},
+			want: ErrorRecord{Kind: StreamErrorKind, Message: "Stream tcp://localhost:19099 error: connection refused", Source: "tcp://localhost:19099"},
+		},
+		{
+			name: "Convert duplicate error",
+			err:  &DuplicateError{UserId: 7, Name: "James Barret", Policy: KeepLastDuplicate},
+			want: ErrorRecord{Kind: DuplicateErrorKind, Message: "Duplicate customer [7] James Barret: replaced by the last record", UserId: &userId},
+		},
+		{
+			name: "Convert generic error",
+			err:  errors.New("context canceled"),
+			want: ErrorRecord{Kind: GenericErrorKind, Message: "context canceled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToErrorRecord(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToErrorRecord() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewErrorReport(t *testing.T) {
+	got := NewErrorReport([]error{errors.New("first"), errors.New("second")})
+	if got.Count != 2 || len(got.Errors) != 2 || got.Errors[1].Message != "second" {
+		t.Errorf("NewErrorReport() = %+v, want 2 errors", got)
+	}
+	if got := NewErrorReport(nil); got.Count != 0 || got.Errors == nil {
+		t.Errorf("NewErrorReport() = %+v, want empty errors list", got)
+	}
+}
@@ -9,7 +9,6 @@
 package model
 
 import (
-	"strconv"
 	"sync"
 )
 
@@ -26,30 +25,73 @@ type CustomerOfficeList struct {
 	List []CustomerOffice `json:"customers,omitempty" yaml:"customers,omitempty" xml:"customers,omitempty"`
 }
 
+// Get the latitude in decimal degrees, the value can be in decimal degrees or in degrees,
+// minutes, seconds notation (e.g.: 53°20'21.9"N), a *ValidationError reports an invalid value
 func (c *CustomerOffice) GetLatitude() (float64, error) {
-	return strconv.ParseFloat(c.Latitude, 64)
+	return latitudeAxis.parse(c.Latitude)
 }
 
+// Get the longitude in decimal degrees, the value can be in decimal degrees or in degrees,
+// minutes, seconds notation (e.g.: 6°15'27.4"W), a *ValidationError reports an invalid value
 func (c *CustomerOffice) GetLongitude() (float64, error) {
-	return strconv.ParseFloat(c.Longitude, 64)
+	return longitudeAxis.parse(c.Longitude)
+}
+
+// Verify the coordinates are finite numbers in the latitude [-90, 90] and longitude
+// [-180, 180] ranges, reporting the *ValidationError of the first invalid coordinate
+func (c *CustomerOffice) Validate() error {
+	if _, err := c.GetLatitude(); err != nil {
+		return err
+	}
+	_, err := c.GetLongitude()
+	return err
 }
 
 func (c *CustomerOffice) IsValid() bool {
-	_, err1 := c.GetLongitude()
-	_, err2 := c.GetLatitude()
-	return err1 == nil && err2 == nil
+	return c.Validate() == nil
+}
+
+// Describe Output Customer office position and distance from the home coordinates
+type CustomerLocation struct {
+	Latitude  float64 `json:"latitude" yaml:"latitude" xml:"latitude"`
+	Longitude float64 `json:"longitude" yaml:"longitude" xml:"longitude"`
+	Distance  float64 `json:"distance" yaml:"distance" xml:"distance"`
+	Unit      string  `json:"unit" yaml:"unit" xml:"unit"`
 }
 
 // Describe Output Customer details unit
 type CustomerDetails struct {
-	UserId int64  `json:"user_id" yaml:"user_id" xml:"user-id"`
-	Name   string `json:"name" yaml:"name" xml:"name"`
+	UserId   int64             `json:"user_id" yaml:"user_id" xml:"user-id"`
+	Name     string            `json:"name" yaml:"name" xml:"name"`
+	Location *CustomerLocation `json:"location,omitempty" yaml:"location,omitempty" xml:"location,omitempty"`
+	// Assigned venue, reported by the venues lists grouping
+	Venue string `json:"-" yaml:"-" xml:"-"`
+	// Computed distance from the home coordinates, used for sorting the output
+	Distance float64 `json:"-" yaml:"-" xml:"-"`
+}
+
+// Get the customer office distance from the home coordinates, used for sorting the output
+func (c *CustomerDetails) GetDistance() float64 {
+	if c.Location != nil {
+		return c.Location.Distance
+	}
+	return c.Distance
+}
+
+// Describe Output customer that cannot be evaluated, with the input coordinates and the reason
+type RejectedCustomer struct {
+	UserId    int64  `json:"user_id" yaml:"user_id" xml:"user-id"`
+	Name      string `json:"name" yaml:"name" xml:"name"`
+	Latitude  string `json:"latitude" yaml:"latitude" xml:"latitude"`
+	Longitude string `json:"longitude" yaml:"longitude" xml:"longitude"`
+	Reason    string `json:"reason" yaml:"reason" xml:"reason"`
 }
 
 // Describe standard output list
 type InviteList struct {
 	m           sync.Mutex
 	CustomerIds []CustomerDetails `json:"customers_list" yaml:"customers_list" xml:"customers-list"`
+	Venues      []VenueInviteList `json:"venues_list,omitempty" yaml:"venues_list,omitempty" xml:"venues-list,omitempty"`
 }
 
 // Add a new customer id to the invited customers list
@@ -73,8 +115,11 @@ func (il *InviteList) Add(customerId *CustomerDetails) bool {
 type CompleteInviteList struct {
 	m1                    sync.Mutex
 	m2                    sync.Mutex
-	MatchingCustomerIds   []CustomerDetails `json:"customers_list" yaml:"customers_list" xml:"customers-list"`
-	UnMatchingCustomerIds []CustomerDetails `json:"exclusions_list" yaml:"exclusions_list" xml:"exclusions-list"`
+	m3                    sync.Mutex
+	MatchingCustomerIds   []CustomerDetails  `json:"customers_list" yaml:"customers_list" xml:"customers-list"`
+	UnMatchingCustomerIds []CustomerDetails  `json:"exclusions_list" yaml:"exclusions_list" xml:"exclusions-list"`
+	RejectedCustomerIds   []RejectedCustomer `json:"rejections_list,omitempty" yaml:"rejections_list,omitempty" xml:"rejections-list,omitempty"`
+	Venues                []VenueInviteList  `json:"venues_list,omitempty" yaml:"venues_list,omitempty" xml:"venues-list,omitempty"`
 }
 
 // Add a new customer id to the invited customers list
@@ -111,6 +156,23 @@ func (il *CompleteInviteList) AddExcluded(customerId *CustomerDetails) bool {
 	return true
 }
 
+// Add a new customer to the rejected customers list
+func (il *CompleteInviteList) AddRejected(customer *RejectedCustomer) bool {
+	defer func() {
+		_ = recover()
+		il.m3.Unlock()
+	}()
+	il.m3.Lock()
+	if il.RejectedCustomerIds == nil {
+		il.RejectedCustomerIds = make([]RejectedCustomer, 0)
+	}
+	if customer == nil {
+		return false
+	}
+	il.RejectedCustomerIds = append(il.RejectedCustomerIds, *customer)
+	return true
+}
+
 // Transform data from input to output data type
 func ToInviteData(customerData *CustomerOffice) *CustomerDetails {
 	if customerData == nil {
@@ -122,6 +184,42 @@ func ToInviteData(customerData *CustomerOffice) *CustomerDetails {
 	}
 }
 
+// Transform data from input to output data type, keeping the computed distance for sorting
+func ToDistanceInviteData(customerData *CustomerOffice, distance float64) *CustomerDetails {
+	details := ToInviteData(customerData)
+	if details != nil {
+		details.Distance = distance
+	}
+	return details
+}
+
+// Transform data from input to detailed output data type, reporting the computed location
+func ToDetailedInviteData(customerData *CustomerOffice, location CustomerLocation) *CustomerDetails {
+	details := ToDistanceInviteData(customerData, location.Distance)
+	if details != nil {
+		details.Location = &location
+	}
+	return details
+}
+
+// Transform data from input to rejected output data type, reporting the raw coordinates and the
+// rejection reason
+func ToRejectedData(customerData *CustomerOffice, reason error) *RejectedCustomer {
+	if customerData == nil {
+		return nil
+	}
+	rejected := &RejectedCustomer{
+		UserId:    customerData.UserId,
+		Name:      customerData.Name,
+		Latitude:  customerData.Latitude,
+		Longitude: customerData.Longitude,
+	}
+	if reason != nil {
+		rejected.Reason = reason.Error()
+	}
+	return rejected
+}
+
 // Creates a simple output invitation list bucket pointer
 func NewInviteList() *InviteList {
 	return &InviteList{
@@ -133,9 +231,12 @@ func NewInviteList() *InviteList {
 // Creates a detailed output invitation list bucket pointer
 func NewCompleteInviteList() *CompleteInviteList {
 	return &CompleteInviteList{
+		sync.Mutex{},
 		sync.Mutex{},
 		sync.Mutex{},
 		make([]CustomerDetails, 0),
 		make([]CustomerDetails, 0),
+		make([]RejectedCustomer, 0),
+		nil,
 	}
 }
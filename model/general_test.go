@@ -9,6 +9,7 @@
 package model
 
 import (
+	"errors"
 	"reflect"
 	"sync"
 	"testing"
@@ -69,6 +70,38 @@ func TestCompleteInviteList_AddExcluded(t *testing.T) {
 	}
 }
 
+func TestCompleteInviteList_AddRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		customer *RejectedCustomer
+		want     bool
+	}{
+		{
+			name:     "Test insert nil rejected customer element",
+			customer: nil,
+			want:     false,
+		},
+		{
+			name: "Test insert sample rejected customer element",
+			customer: ToRejectedData(&CustomerOffice{
+				UserId:    1,
+				Name:      "James Barrett",
+				Latitude:  "north",
+				Longitude: "-5.2653335",
+			}, errors.New("invalid latitude")),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			il := NewCompleteInviteList()
+			if got := il.AddRejected(tt.customer); got != tt.want {
+				t.Errorf("AddRejected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCompleteInviteList_AddInvited(t *testing.T) {
 	type fields struct {
 		MatchingCustomerIds   []CustomerDetails
@@ -183,10 +216,13 @@ func TestNewCompleteInviteList(t *testing.T) {
 		{
 			name: "Test Creation of a detailed output data",
 			want: &CompleteInviteList{
+				sync.Mutex{},
 				sync.Mutex{},
 				sync.Mutex{},
 				make([]CustomerDetails, 0),
 				make([]CustomerDetails, 0),
+				make([]RejectedCustomer, 0),
+				nil,
 			},
 		},
 	}
@@ -209,6 +245,7 @@ func TestNewInviteList(t *testing.T) {
 			want: &InviteList{
 				sync.Mutex{},
 				make([]CustomerDetails, 0),
+				nil,
 			},
 		},
 	}
@@ -262,6 +299,58 @@ func TestToInviteData(t *testing.T) {
 	}
 }
 
+func TestToDetailedInviteData(t *testing.T) {
+	type args struct {
+		customerData *CustomerOffice
+		location     CustomerLocation
+	}
+	location := CustomerLocation{
+		Latitude:  10.58889684,
+		Longitude: 2.345355,
+		Distance:  12.5,
+		Unit:      "K",
+	}
+	tests := []struct {
+		name string
+		args args
+		want *CustomerDetails
+	}{
+		{
+			name: "Test input to detailed output data transformation, for given data",
+			args: args{
+				&CustomerOffice{
+					UserId:    1,
+					Name:      "Thomas Barrett",
+					Latitude:  "10.58889684",
+					Longitude: "2.345355",
+				},
+				location,
+			},
+			want: &CustomerDetails{
+				UserId:   1,
+				Name:     "Thomas Barrett",
+				Location: &location,
+				Distance: 12.5,
+			},
+		},
+		{
+			name: "Test input to detailed output data transformation, for nil input data",
+			args: args{
+				nil,
+				location,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToDetailedInviteData(tt.args.customerData, tt.args.location); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToDetailedInviteData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCustomerOffice_GetLatitude(t *testing.T) {
 	type fields struct {
 		UserId    int64
@@ -431,3 +520,50 @@ func TestCustomerOffice_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestToRejectedData(t *testing.T) {
+	type args struct {
+		customerData *CustomerOffice
+		reason       error
+	}
+	tests := []struct {
+		name string
+		args args
+		want *RejectedCustomer
+	}{
+		{
+			name: "Test input to rejected output data transformation, for given data",
+			args: args{
+				&CustomerOffice{
+					UserId:    1,
+					Name:      "Thomas Barrett",
+					Latitude:  "north",
+					Longitude: "2.345355",
+				},
+				errors.New("invalid latitude"),
+			},
+			want: &RejectedCustomer{
+				UserId:    1,
+				Name:      "Thomas Barrett",
+				Latitude:  "north",
+				Longitude: "2.345355",
+				Reason:    "invalid latitude",
+			},
+		},
+		{
+			name: "Test input to rejected output data transformation, for nil input data",
+			args: args{
+				nil,
+				errors.New("invalid latitude"),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToRejectedData(tt.args.customerData, tt.args.reason); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToRejectedData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
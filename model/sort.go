@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type SortOrder string
+
+const (
+	NoSort             SortOrder = "none"
+	SortByUserId       SortOrder = "user-id"
+	SortByName         SortOrder = "name"
+	SortByDistanceAsc  SortOrder = "distance-asc"
+	SortByDistanceDesc SortOrder = "distance-desc"
+)
+
+var SortOrders = []string{"none", "user-id", "name", "distance-asc", "distance-desc"}
+
+//  Convert text to SortOrder or return an unknown SortOrder error.
+//
+//  In/
+//  input text to be converted to SortOrder type enumeration, empty text means no sorting
+//
+//  The output are the sort order element and the error, if the sort order text is not known.
+func ToSortOrder(in string) (order SortOrder, err error) {
+	switch strings.ToLower(in) {
+	case "", "none":
+		order = NoSort
+	case "user-id":
+		order = SortByUserId
+	case "name":
+		order = SortByName
+	case "distance-asc":
+		order = SortByDistanceAsc
+	case "distance-desc":
+		order = SortByDistanceDesc
+	default:
+		order = NoSort
+		err = errors.New(fmt.Sprintf("Unknown sort order text: %s", in))
+	}
+	return order, err
+}
+
+//  Sort the customers in place accordingly to the given order. Customers with same sorting key
+//  are ordered by user id, so the output is deterministic.
+//
+//  Customers/
+//  Customers list to be sorted
+//
+//  Order/
+//  Sort order, accordingly to the type model.SortOrder (no sorting for NoSort or empty order)
+func SortCustomers(customers []CustomerDetails, order SortOrder) {
+	var less func(a, b *CustomerDetails) bool
+	switch order {
+	case SortByUserId:
+		less = func(a, b *CustomerDetails) bool {
+			return a.UserId < b.UserId
+		}
+	case SortByName:
+		less = func(a, b *CustomerDetails) bool {
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.UserId < b.UserId
+		}
+	case SortByDistanceAsc:
+		less = func(a, b *CustomerDetails) bool {
+			if a.GetDistance() != b.GetDistance() {
+				return a.GetDistance() < b.GetDistance()
+			}
+			return a.UserId < b.UserId
+		}
+	case SortByDistanceDesc:
+		less = func(a, b *CustomerDetails) bool {
+			if a.GetDistance() != b.GetDistance() {
+				return a.GetDistance() > b.GetDistance()
+			}
+			return a.UserId < b.UserId
+		}
+	default:
+		return
+	}
+	sort.SliceStable(customers, func(i, j int) bool {
+		return less(&customers[i], &customers[j])
+	})
+}
+
+// Sort the invited customers list, also grouped by venue, accordingly to the given order
+func (il *InviteList) Sort(order SortOrder) {
+	il.m.Lock()
+	defer il.m.Unlock()
+	SortCustomers(il.CustomerIds, order)
+	for _, group := range il.Venues {
+		SortCustomers(group.CustomerIds, order)
+	}
+}
+
+// Sort the invited, also grouped by venue, excluded and rejected customers lists accordingly to the given order
+func (il *CompleteInviteList) Sort(order SortOrder) {
+	il.m1.Lock()
+	SortCustomers(il.MatchingCustomerIds, order)
+	for _, group := range il.Venues {
+		SortCustomers(group.CustomerIds, order)
+	}
+	il.m1.Unlock()
+	il.m2.Lock()
+	SortCustomers(il.UnMatchingCustomerIds, order)
+	il.m2.Unlock()
+	il.m3.Lock()
+	SortRejectedCustomers(il.RejectedCustomerIds, order)
+	il.m3.Unlock()
+}
+
+//  Sort the rejected customers in place accordingly to the given order. Rejected customers have
+//  no distance, so the distance orders sort them by user id.
+//
+//  Customers/
+//  Rejected customers list to be sorted
+//
+//  Order/
+//  Sort order, accordingly to the type model.SortOrder (no sorting for NoSort or empty order)
+func SortRejectedCustomers(customers []RejectedCustomer, order SortOrder) {
+	var less func(a, b *RejectedCustomer) bool
+	switch order {
+	case SortByUserId, SortByDistanceAsc, SortByDistanceDesc:
+		less = func(a, b *RejectedCustomer) bool {
+			return a.UserId < b.UserId
+		}
+	case SortByName:
+		less = func(a, b *RejectedCustomer) bool {
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.UserId < b.UserId
+		}
+	default:
+		return
+	}
+	sort.SliceStable(customers, func(i, j int) bool {
+		return less(&customers[i], &customers[j])
+	})
+}
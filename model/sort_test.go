@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToSortOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		wantOrder SortOrder
+		wantErr   bool
+	}{
+		{"Transform empty sort order text", "", NoSort, false},
+		{"Transform user id sort order text", "user-id", SortByUserId, false},
+		{"Transform case sensitive name sort order text", "Name", SortByName, false},
+		{"Transform distance ascending sort order text", "distance-asc", SortByDistanceAsc, false},
+		{"Transform distance descending sort order text", "distance-desc", SortByDistanceDesc, false},
+		{"Transform incorrect sort order text", "age", NoSort, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOrder, err := ToSortOrder(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToSortOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotOrder != tt.wantOrder {
+				t.Errorf("ToSortOrder() gotOrder = %v, want %v", gotOrder, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestSortCustomers(t *testing.T) {
+	customers := func() []CustomerDetails {
+		return []CustomerDetails{
+			*ToDistanceInviteData(&CustomerOffice{UserId: 3, Name: "Alan Behan"}, 25.5),
+			*ToDetailedInviteData(&CustomerOffice{UserId: 1, Name: "Thomas Barret"}, CustomerLocation{Distance: 10}),
+			*ToDistanceInviteData(&CustomerOffice{UserId: 2, Name: "Alan Behan"}, 10),
+			{UserId: 4, Name: "Ian McArdle", Distance: 5},
+		}
+	}
+	tests := []struct {
+		name    string
+		order   SortOrder
+		wantIds []int64
+	}{
+		{"Test no sorting keeps the order", NoSort, []int64{3, 1, 2, 4}},
+		{"Test sorting by user id", SortByUserId, []int64{1, 2, 3, 4}},
+		{"Test sorting by name", SortByName, []int64{2, 3, 4, 1}},
+		{"Test sorting by distance ascending", SortByDistanceAsc, []int64{4, 1, 2, 3}},
+		{"Test sorting by distance descending", SortByDistanceDesc, []int64{3, 1, 2, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := customers()
+			SortCustomers(list, tt.order)
+			gotIds := make([]int64, 0)
+			for _, c := range list {
+				gotIds = append(gotIds, c.UserId)
+			}
+			if !reflect.DeepEqual(gotIds, tt.wantIds) {
+				t.Errorf("SortCustomers() got = %v, want %v", gotIds, tt.wantIds)
+			}
+		})
+	}
+}
+
+func TestCompleteInviteList_Sort(t *testing.T) {
+	il := NewCompleteInviteList()
+	il.AddInvited(&CustomerDetails{UserId: 2, Name: "Thomas Barret"})
+	il.AddInvited(&CustomerDetails{UserId: 1, Name: "Michael Barret"})
+	il.AddExcluded(&CustomerDetails{UserId: 4, Name: "Alan Behan"})
+	il.AddExcluded(&CustomerDetails{UserId: 3, Name: "Nora Dempsey"})
+	il.AddRejected(&RejectedCustomer{UserId: 6, Name: "Alan Behan"})
+	il.AddRejected(&RejectedCustomer{UserId: 5, Name: "Nora Dempsey"})
+	il.Sort(SortByUserId)
+	if il.MatchingCustomerIds[0].UserId != 1 || il.UnMatchingCustomerIds[0].UserId != 3 || il.RejectedCustomerIds[0].UserId != 5 {
+		t.Errorf("Sort() got = %+v, %+v, %+v, want lists sorted by user id", il.MatchingCustomerIds, il.UnMatchingCustomerIds, il.RejectedCustomerIds)
+	}
+	il.Sort(SortByName)
+	if il.RejectedCustomerIds[0].UserId != 6 {
+		t.Errorf("Sort() got = %+v, want rejected list sorted by name", il.RejectedCustomerIds)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Scalar input value, given either as a string or as a number
+type lenientValue struct {
+	text string
+	set  bool
+}
+
+func (v *lenientValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &v.text); err != nil {
+			return err
+		}
+	} else {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return errors.New(fmt.Sprintf("expected a string or a number, found %s", data))
+		}
+		v.text = number.String()
+	}
+	v.set = true
+	return nil
+}
+
+func (v *lenientValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// Yaml numbers are accepted as strings, the null value is skipped
+	var text *string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	if text != nil {
+		v.text, v.set = *text, true
+	}
+	return nil
+}
+
+func (v *lenientValue) UnmarshalText(data []byte) error {
+	v.text, v.set = strings.TrimSpace(string(data)), true
+	return nil
+}
+
+// Customer office record, as given in the input documents
+type customerOfficeDocument struct {
+	UserId    lenientValue `json:"user_id" yaml:"user_id" xml:"user-id"`
+	Name      *string      `json:"name" yaml:"name" xml:"name"`
+	Latitude  lenientValue `json:"latitude" yaml:"latitude" xml:"latitude"`
+	Longitude lenientValue `json:"longitude" yaml:"longitude" xml:"longitude"`
+}
+
+// Copy the given fields to the customer office, converting the user id to a number
+func (d *customerOfficeDocument) apply(c *CustomerOffice) error {
+	if d.UserId.set {
+		userId, err := strconv.ParseInt(strings.TrimSpace(d.UserId.text), 10, 64)
+		if err != nil {
+			return errors.New(fmt.Sprintf("invalid user_id \"%s\": not an integer number", d.UserId.text))
+		}
+		c.UserId = userId
+	}
+	if d.Name != nil {
+		c.Name = *d.Name
+	}
+	if d.Latitude.set {
+		c.Latitude = d.Latitude.text
+	}
+	if d.Longitude.set {
+		c.Longitude = d.Longitude.text
+	}
+	return nil
+}
+
+// Decode a json customer office, where the user id and the coordinates can be strings or numbers
+func (c *CustomerOffice) UnmarshalJSON(data []byte) error {
+	var document customerOfficeDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		// The type errors keep their offset, relative to the record
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			typeError.Struct = "CustomerOffice"
+		}
+		return err
+	}
+	if err := document.apply(c); err != nil {
+		// Reported as a type error at the record start, so the record can be located in the document
+		return &json.UnmarshalTypeError{Value: fmt.Sprintf("string %q", document.UserId.text), Type: reflect.TypeOf(c.UserId), Struct: "CustomerOffice", Field: "user_id"}
+	}
+	return nil
+}
+
+// Decode a yaml customer office, where the user id and the coordinates can be strings or numbers
+func (c *CustomerOffice) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var document customerOfficeDocument
+	if err := unmarshal(&document); err != nil {
+		return err
+	}
+	return document.apply(c)
+}
+
+// Decode a xml customer office, where the user id is accepted with surrounding spaces
+func (c *CustomerOffice) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var document customerOfficeDocument
+	if err := d.DecodeElement(&document, &start); err != nil {
+		return err
+	}
+	return document.apply(c)
+}
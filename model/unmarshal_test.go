@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"gopkg.in/yaml.v2"
+	"reflect"
+	"testing"
+)
+
+func TestCustomerOffice_Unmarshal(t *testing.T) {
+	customer := CustomerOffice{
+		UserId:    12,
+		Name:      "Christina McArdle",
+		Latitude:  "52.986375",
+		Longitude: "-6.043701",
+	}
+	unmarshal := map[string]func(data []byte, v interface{}) error{
+		"json": json.Unmarshal,
+		"yaml": yaml.Unmarshal,
+		"xml":  xml.Unmarshal,
+	}
+	tests := []struct {
+		name         string
+		enc          string
+		data         string
+		wantCustomer CustomerOffice
+		wantErr      bool
+	}{
+		{
+			name:         "Test json string fields",
+			enc:          "json",
+			data:         `{"user_id": 12, "name": "Christina McArdle", "latitude": "52.986375", "longitude": "-6.043701"}`,
+			wantCustomer: customer,
+		},
+		{
+			name:         "Test json numeric coordinates and string user id",
+			enc:          "json",
+			data:         `{"user_id": "12", "name": "Christina McArdle", "latitude": 52.986375, "longitude": -6.043701}`,
+			wantCustomer: customer,
+		},
+		{
+			name:         "Test json null fields are skipped",
+			enc:          "json",
+			data:         `{"user_id": 12, "name": "Christina McArdle", "latitude": null, "longitude": -6.043701}`,
+			wantCustomer: CustomerOffice{UserId: 12, Name: "Christina McArdle", Longitude: "-6.043701"},
+		},
+		{
+			name:    "Test json not integer user id",
+			enc:     "json",
+			data:    `{"user_id": "twelve", "name": "Christina McArdle"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Test json boolean coordinate",
+			enc:     "json",
+			data:    `{"user_id": 12, "latitude": true}`,
+			wantErr: true,
+		},
+		{
+			name:    "Test json numeric name",
+			enc:     "json",
+			data:    `{"user_id": 12, "name": 5}`,
+			wantErr: true,
+		},
+		{
+			name:         "Test yaml numeric coordinates and string user id",
+			enc:          "yaml",
+			data:         "user_id: \"12\"\nname: Christina McArdle\nlatitude: 52.986375\nlongitude: -6.043701\n",
+			wantCustomer: customer,
+		},
+		{
+			name:    "Test yaml not integer user id",
+			enc:     "yaml",
+			data:    "user_id: twelve\nname: Christina McArdle\n",
+			wantErr: true,
+		},
+		{
+			name:         "Test xml user id with spaces",
+			enc:          "xml",
+			data:         "<CustomerOffice><user-id> 12 </user-id><name>Christina McArdle</name><latitude>52.986375</latitude><longitude>-6.043701</longitude></CustomerOffice>",
+			wantCustomer: customer,
+		},
+		{
+			name:    "Test xml not integer user id",
+			enc:     "xml",
+			data:    "<CustomerOffice><user-id>twelve</user-id></CustomerOffice>",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCustomer CustomerOffice
+			err := unmarshal[tt.enc]([]byte(tt.data), &gotCustomer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(gotCustomer, tt.wantCustomer) {
+				t.Errorf("Unmarshal() gotCustomer = %+v, want %+v", gotCustomer, tt.wantCustomer)
+			}
+		})
+	}
+}
+
+func TestCustomerOffice_UnmarshalJSON_typeErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantField  string
+		wantOffset int64
+	}{
+		{"Test not integer user id at the record start", `{"user_id": "twelve", "name": "Christina McArdle"}`, "user_id", 0},
+		{"Test numeric name at the field value", `{"user_id": 12, "name": 5}`, "name", 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var customer CustomerOffice
+			err := json.Unmarshal([]byte(tt.data), &customer)
+			typeError, ok := err.(*json.UnmarshalTypeError)
+			if !ok {
+				t.Errorf("UnmarshalJSON() error = %v, want *json.UnmarshalTypeError", err)
+				return
+			}
+			if typeError.Field != tt.wantField || typeError.Offset != tt.wantOffset {
+				t.Errorf("UnmarshalJSON() field = %v and offset = %v, want %v and %v", typeError.Field, typeError.Offset, tt.wantField, tt.wantOffset)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+// Describe an event venue, inviting the customers within the radius
+type Venue struct {
+	Name      string  `json:"name" yaml:"name" xml:"name"`
+	Latitude  float64 `json:"latitude" yaml:"latitude" xml:"latitude"`
+	Longitude float64 `json:"longitude" yaml:"longitude" xml:"longitude"`
+	Radius    float64 `json:"radius" yaml:"radius" xml:"radius"`
+}
+
+// Describe input venues information
+type VenueList struct {
+	List []Venue `json:"venues,omitempty" yaml:"venues,omitempty" xml:"venues,omitempty"`
+}
+
+// Describe the customers invited to a venue
+type VenueInviteList struct {
+	Venue       string            `json:"venue" yaml:"venue" xml:"venue"`
+	CustomerIds []CustomerDetails `json:"customers_list" yaml:"customers_list" xml:"customers-list"`
+}
+
+// Add a venue group, if not present yet, to the invited customers list
+func (il *InviteList) AddVenue(venue string) {
+	il.m.Lock()
+	defer il.m.Unlock()
+	il.Venues = addVenueGroup(il.Venues, venue)
+}
+
+// Add a new customer id to the invited customers list of the given venue
+func (il *InviteList) AddToVenue(venue string, customerId *CustomerDetails) bool {
+	if customerId == nil {
+		return false
+	}
+	il.m.Lock()
+	defer il.m.Unlock()
+	il.Venues = addVenueCustomer(il.Venues, venue, *customerId)
+	return true
+}
+
+// Add a venue group, if not present yet, to the invited customers list
+func (il *CompleteInviteList) AddVenue(venue string) {
+	il.m1.Lock()
+	defer il.m1.Unlock()
+	il.Venues = addVenueGroup(il.Venues, venue)
+}
+
+// Add a new customer id to the invited customers list of the given venue
+func (il *CompleteInviteList) AddInvitedToVenue(venue string, customerId *CustomerDetails) bool {
+	if customerId == nil {
+		return false
+	}
+	il.m1.Lock()
+	defer il.m1.Unlock()
+	il.Venues = addVenueCustomer(il.Venues, venue, *customerId)
+	return true
+}
+
+func addVenueGroup(venues []VenueInviteList, venue string) []VenueInviteList {
+	for _, group := range venues {
+		if group.Venue == venue {
+			return venues
+		}
+	}
+	return append(venues, VenueInviteList{
+		Venue:       venue,
+		CustomerIds: make([]CustomerDetails, 0),
+	})
+}
+
+func addVenueCustomer(venues []VenueInviteList, venue string, customer CustomerDetails) []VenueInviteList {
+	venues = addVenueGroup(venues, venue)
+	for i := range venues {
+		if venues[i].Venue == venue {
+			venues[i].CustomerIds = append(venues[i].CustomerIds, customer)
+		}
+	}
+	return venues
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package model
+
+import (
+	"testing"
+)
+
+func TestInviteList_AddToVenue(t *testing.T) {
+	il := NewInviteList()
+	il.AddVenue("Dublin")
+	il.AddVenue("Cork")
+	il.AddVenue("Dublin")
+	if got := il.AddToVenue("Cork", nil); got {
+		t.Errorf("AddToVenue() = %v, want %v", got, false)
+	}
+	if got := il.AddToVenue("Cork", &CustomerDetails{UserId: 1, Name: "Thomas Barret"}); !got {
+		t.Errorf("AddToVenue() = %v, want %v", got, true)
+	}
+	if got := il.AddToVenue("Galway", &CustomerDetails{UserId: 2, Name: "Michael Barret"}); !got {
+		t.Errorf("AddToVenue() = %v, want %v", got, true)
+	}
+	if len(il.Venues) != 3 {
+		t.Errorf("AddToVenue() Venues = %+v, want %v venues", il.Venues, 3)
+		return
+	}
+	wantCustomers := map[string]int{"Dublin": 0, "Cork": 1, "Galway": 1}
+	for i, venue := range []string{"Dublin", "Cork", "Galway"} {
+		if il.Venues[i].Venue != venue || len(il.Venues[i].CustomerIds) != wantCustomers[venue] {
+			t.Errorf("AddToVenue() Venues[%v] = %+v, want venue %s with %v customers", i, il.Venues[i], venue, wantCustomers[venue])
+		}
+	}
+	if len(il.CustomerIds) != 0 {
+		t.Errorf("AddToVenue() CustomerIds = %+v, want empty list", il.CustomerIds)
+	}
+}
+
+func TestCompleteInviteList_AddInvitedToVenue(t *testing.T) {
+	il := NewCompleteInviteList()
+	il.AddVenue("Dublin")
+	if got := il.AddInvitedToVenue("Dublin", nil); got {
+		t.Errorf("AddInvitedToVenue() = %v, want %v", got, false)
+	}
+	if got := il.AddInvitedToVenue("Dublin", &CustomerDetails{UserId: 1, Name: "Thomas Barret"}); !got {
+		t.Errorf("AddInvitedToVenue() = %v, want %v", got, true)
+	}
+	if len(il.Venues) != 1 || len(il.Venues[0].CustomerIds) != 1 {
+		t.Errorf("AddInvitedToVenue() Venues = %+v, want one customer in venue Dublin", il.Venues)
+	}
+	if len(il.MatchingCustomerIds) != 0 {
+		t.Errorf("AddInvitedToVenue() MatchingCustomerIds = %+v, want empty list", il.MatchingCustomerIds)
+	}
+}
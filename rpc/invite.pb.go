@@ -0,0 +1,1669 @@
+// Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+// https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+//
+// Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+// You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.12.4
+// source: invite.proto
+
+package rpc
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// Input customer office information, mirroring model.CustomerOffice
+type CustomerOffice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId int64  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Decimal degrees or degrees, minutes, seconds notation
+	Latitude  string `protobuf:"bytes,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude string `protobuf:"bytes,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+}
+
+func (x *CustomerOffice) Reset() {
+	*x = CustomerOffice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomerOffice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerOffice) ProtoMessage() {}
+
+func (x *CustomerOffice) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerOffice.ProtoReflect.Descriptor instead.
+func (*CustomerOffice) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CustomerOffice) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CustomerOffice) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomerOffice) GetLatitude() string {
+	if x != nil {
+		return x.Latitude
+	}
+	return ""
+}
+
+func (x *CustomerOffice) GetLongitude() string {
+	if x != nil {
+		return x.Longitude
+	}
+	return ""
+}
+
+// Event venue, mirroring model.Venue
+type Venue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude  float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Radius    float64 `protobuf:"fixed64,4,opt,name=radius,proto3" json:"radius,omitempty"`
+}
+
+func (x *Venue) Reset() {
+	*x = Venue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Venue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Venue) ProtoMessage() {}
+
+func (x *Venue) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Venue.ProtoReflect.Descriptor instead.
+func (*Venue) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Venue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Venue) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Venue) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Venue) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+// Scan parameters, mirroring invite.InputData
+type InputData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HomeLatitude  float64 `protobuf:"fixed64,1,opt,name=home_latitude,json=homeLatitude,proto3" json:"home_latitude,omitempty"`
+	HomeLongitude float64 `protobuf:"fixed64,2,opt,name=home_longitude,json=homeLongitude,proto3" json:"home_longitude,omitempty"`
+	Distance      float64 `protobuf:"fixed64,3,opt,name=distance,proto3" json:"distance,omitempty"`
+	// Measure unit of the distance: K, M, N, MT, FT or YD
+	MeasureUnit       string `protobuf:"bytes,4,opt,name=measure_unit,json=measureUnit,proto3" json:"measure_unit,omitempty"`
+	UseDetailedOutput bool   `protobuf:"varint,5,opt,name=use_detailed_output,json=useDetailedOutput,proto3" json:"use_detailed_output,omitempty"`
+	// Output lists order: none, user-id, name, distance-asc or distance-desc
+	SortOrder string `protobuf:"bytes,6,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	// Distance algorithm: cosines, haversine or vincenty
+	Algorithm string `protobuf:"bytes,7,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// GeoJSON document of the catchment area, if any
+	Geofence []byte   `protobuf:"bytes,8,opt,name=geofence,proto3" json:"geofence,omitempty"`
+	Venues   []*Venue `protobuf:"bytes,9,rep,name=venues,proto3" json:"venues,omitempty"`
+	// Policy of the customers with the same user id: first, last or error
+	DuplicatePolicy string `protobuf:"bytes,10,opt,name=duplicate_policy,json=duplicatePolicy,proto3" json:"duplicate_policy,omitempty"`
+	// Number of concurrent evaluation workers, zero uses the number of CPUs
+	WorkerPoolSize int32 `protobuf:"varint,11,opt,name=worker_pool_size,json=workerPoolSize,proto3" json:"worker_pool_size,omitempty"`
+}
+
+func (x *InputData) Reset() {
+	*x = InputData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InputData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputData) ProtoMessage() {}
+
+func (x *InputData) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputData.ProtoReflect.Descriptor instead.
+func (*InputData) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InputData) GetHomeLatitude() float64 {
+	if x != nil {
+		return x.HomeLatitude
+	}
+	return 0
+}
+
+func (x *InputData) GetHomeLongitude() float64 {
+	if x != nil {
+		return x.HomeLongitude
+	}
+	return 0
+}
+
+func (x *InputData) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *InputData) GetMeasureUnit() string {
+	if x != nil {
+		return x.MeasureUnit
+	}
+	return ""
+}
+
+func (x *InputData) GetUseDetailedOutput() bool {
+	if x != nil {
+		return x.UseDetailedOutput
+	}
+	return false
+}
+
+func (x *InputData) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *InputData) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *InputData) GetGeofence() []byte {
+	if x != nil {
+		return x.Geofence
+	}
+	return nil
+}
+
+func (x *InputData) GetVenues() []*Venue {
+	if x != nil {
+		return x.Venues
+	}
+	return nil
+}
+
+func (x *InputData) GetDuplicatePolicy() string {
+	if x != nil {
+		return x.DuplicatePolicy
+	}
+	return ""
+}
+
+func (x *InputData) GetWorkerPoolSize() int32 {
+	if x != nil {
+		return x.WorkerPoolSize
+	}
+	return 0
+}
+
+// Customer office position and distance, mirroring model.CustomerLocation
+type CustomerLocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Distance  float64 `protobuf:"fixed64,3,opt,name=distance,proto3" json:"distance,omitempty"`
+	Unit      string  `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+}
+
+func (x *CustomerLocation) Reset() {
+	*x = CustomerLocation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomerLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerLocation) ProtoMessage() {}
+
+func (x *CustomerLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerLocation.ProtoReflect.Descriptor instead.
+func (*CustomerLocation) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CustomerLocation) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *CustomerLocation) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *CustomerLocation) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *CustomerLocation) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// Output customer details, mirroring model.CustomerDetails
+type CustomerDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   int64             `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name     string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location *CustomerLocation `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Venue    string            `protobuf:"bytes,4,opt,name=venue,proto3" json:"venue,omitempty"`
+}
+
+func (x *CustomerDetails) Reset() {
+	*x = CustomerDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomerDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerDetails) ProtoMessage() {}
+
+func (x *CustomerDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerDetails.ProtoReflect.Descriptor instead.
+func (*CustomerDetails) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CustomerDetails) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CustomerDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomerDetails) GetLocation() *CustomerLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *CustomerDetails) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+// Customer that cannot be evaluated, mirroring model.RejectedCustomer
+type RejectedCustomer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    int64  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude  string `protobuf:"bytes,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude string `protobuf:"bytes,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Reason    string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RejectedCustomer) Reset() {
+	*x = RejectedCustomer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectedCustomer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedCustomer) ProtoMessage() {}
+
+func (x *RejectedCustomer) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedCustomer.ProtoReflect.Descriptor instead.
+func (*RejectedCustomer) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RejectedCustomer) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RejectedCustomer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RejectedCustomer) GetLatitude() string {
+	if x != nil {
+		return x.Latitude
+	}
+	return ""
+}
+
+func (x *RejectedCustomer) GetLongitude() string {
+	if x != nil {
+		return x.Longitude
+	}
+	return ""
+}
+
+func (x *RejectedCustomer) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Customers invited to a venue, mirroring model.VenueInviteList
+type VenueInviteList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Venue     string             `protobuf:"bytes,1,opt,name=venue,proto3" json:"venue,omitempty"`
+	Customers []*CustomerDetails `protobuf:"bytes,2,rep,name=customers,proto3" json:"customers,omitempty"`
+}
+
+func (x *VenueInviteList) Reset() {
+	*x = VenueInviteList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VenueInviteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VenueInviteList) ProtoMessage() {}
+
+func (x *VenueInviteList) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VenueInviteList.ProtoReflect.Descriptor instead.
+func (*VenueInviteList) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VenueInviteList) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *VenueInviteList) GetCustomers() []*CustomerDetails {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+// Scan error, mirroring model.ErrorRecord
+type ScanError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind    string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Line    int32  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	Offset  int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Snippet string `protobuf:"bytes,5,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	UserId  int64  `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Source  string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *ScanError) Reset() {
+	*x = ScanError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScanError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanError) ProtoMessage() {}
+
+func (x *ScanError) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanError.ProtoReflect.Descriptor instead.
+func (*ScanError) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScanError) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ScanError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ScanError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ScanError) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ScanError) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *ScanError) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ScanError) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// Scan result, mirroring invite.OutputData, excluded and rejected customers are reported by
+// the detailed output only. The errors are followed by the duplicate records dropped by the
+// first and last wins policies, which are not scan errors and leave is_done set
+type OutputData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invited    []*CustomerDetails  `protobuf:"bytes,1,rep,name=invited,proto3" json:"invited,omitempty"`
+	Excluded   []*CustomerDetails  `protobuf:"bytes,2,rep,name=excluded,proto3" json:"excluded,omitempty"`
+	Rejected   []*RejectedCustomer `protobuf:"bytes,3,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	Venues     []*VenueInviteList  `protobuf:"bytes,4,rep,name=venues,proto3" json:"venues,omitempty"`
+	IsComplete bool                `protobuf:"varint,5,opt,name=is_complete,json=isComplete,proto3" json:"is_complete,omitempty"`
+	IsDone     bool                `protobuf:"varint,6,opt,name=is_done,json=isDone,proto3" json:"is_done,omitempty"`
+	Duplicates int32               `protobuf:"varint,7,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Errors     []*ScanError        `protobuf:"bytes,8,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *OutputData) Reset() {
+	*x = OutputData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutputData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputData) ProtoMessage() {}
+
+func (x *OutputData) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputData.ProtoReflect.Descriptor instead.
+func (*OutputData) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OutputData) GetInvited() []*CustomerDetails {
+	if x != nil {
+		return x.Invited
+	}
+	return nil
+}
+
+func (x *OutputData) GetExcluded() []*CustomerDetails {
+	if x != nil {
+		return x.Excluded
+	}
+	return nil
+}
+
+func (x *OutputData) GetRejected() []*RejectedCustomer {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+func (x *OutputData) GetVenues() []*VenueInviteList {
+	if x != nil {
+		return x.Venues
+	}
+	return nil
+}
+
+func (x *OutputData) GetIsComplete() bool {
+	if x != nil {
+		return x.IsComplete
+	}
+	return false
+}
+
+func (x *OutputData) GetIsDone() bool {
+	if x != nil {
+		return x.IsDone
+	}
+	return false
+}
+
+func (x *OutputData) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *OutputData) GetErrors() []*ScanError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type InviteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Input     *InputData        `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	Customers []*CustomerOffice `protobuf:"bytes,2,rep,name=customers,proto3" json:"customers,omitempty"`
+}
+
+func (x *InviteRequest) Reset() {
+	*x = InviteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteRequest) ProtoMessage() {}
+
+func (x *InviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteRequest.ProtoReflect.Descriptor instead.
+func (*InviteRequest) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InviteRequest) GetInput() *InputData {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *InviteRequest) GetCustomers() []*CustomerOffice {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+type StreamInviteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*StreamInviteRequest_Input
+	//	*StreamInviteRequest_Customer
+	Request isStreamInviteRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *StreamInviteRequest) Reset() {
+	*x = StreamInviteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamInviteRequest) ProtoMessage() {}
+
+func (x *StreamInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamInviteRequest.ProtoReflect.Descriptor instead.
+func (*StreamInviteRequest) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{10}
+}
+
+func (m *StreamInviteRequest) GetRequest() isStreamInviteRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *StreamInviteRequest) GetInput() *InputData {
+	if x, ok := x.GetRequest().(*StreamInviteRequest_Input); ok {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *StreamInviteRequest) GetCustomer() *CustomerOffice {
+	if x, ok := x.GetRequest().(*StreamInviteRequest_Customer); ok {
+		return x.Customer
+	}
+	return nil
+}
+
+type isStreamInviteRequest_Request interface {
+	isStreamInviteRequest_Request()
+}
+
+type StreamInviteRequest_Input struct {
+	Input *InputData `protobuf:"bytes,1,opt,name=input,proto3,oneof"`
+}
+
+type StreamInviteRequest_Customer struct {
+	Customer *CustomerOffice `protobuf:"bytes,2,opt,name=customer,proto3,oneof"`
+}
+
+func (*StreamInviteRequest_Input) isStreamInviteRequest_Request() {}
+
+func (*StreamInviteRequest_Customer) isStreamInviteRequest_Request() {}
+
+// Invitation decision of a customer, mirroring io.Decision
+type Decision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// invited, excluded or rejected
+	Decision string            `protobuf:"bytes,1,opt,name=decision,proto3" json:"decision,omitempty"`
+	UserId   int64             `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name     string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Venue    string            `protobuf:"bytes,4,opt,name=venue,proto3" json:"venue,omitempty"`
+	Location *CustomerLocation `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Reason   string            `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *Decision) Reset() {
+	*x = Decision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decision) ProtoMessage() {}
+
+func (x *Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decision.ProtoReflect.Descriptor instead.
+func (*Decision) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Decision) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *Decision) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Decision) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Decision) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *Decision) GetLocation() *CustomerLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Decision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type StreamInviteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*StreamInviteResponse_Decision
+	//	*StreamInviteResponse_Error
+	Response isStreamInviteResponse_Response `protobuf_oneof:"response"`
+}
+
+func (x *StreamInviteResponse) Reset() {
+	*x = StreamInviteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invite_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamInviteResponse) ProtoMessage() {}
+
+func (x *StreamInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invite_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamInviteResponse.ProtoReflect.Descriptor instead.
+func (*StreamInviteResponse) Descriptor() ([]byte, []int) {
+	return file_invite_proto_rawDescGZIP(), []int{12}
+}
+
+func (m *StreamInviteResponse) GetResponse() isStreamInviteResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (x *StreamInviteResponse) GetDecision() *Decision {
+	if x, ok := x.GetResponse().(*StreamInviteResponse_Decision); ok {
+		return x.Decision
+	}
+	return nil
+}
+
+func (x *StreamInviteResponse) GetError() *ScanError {
+	if x, ok := x.GetResponse().(*StreamInviteResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isStreamInviteResponse_Response interface {
+	isStreamInviteResponse_Response()
+}
+
+type StreamInviteResponse_Decision struct {
+	Decision *Decision `protobuf:"bytes,1,opt,name=decision,proto3,oneof"`
+}
+
+type StreamInviteResponse_Error struct {
+	Error *ScanError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*StreamInviteResponse_Decision) isStreamInviteResponse_Response() {}
+
+func (*StreamInviteResponse_Error) isStreamInviteResponse_Response() {}
+
+var File_invite_proto protoreflect.FileDescriptor
+
+var file_invite_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x22, 0x77, 0x0a, 0x0e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x4f, 0x66, 0x66, 0x69, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22,
+	0x6d, 0x0a, 0x05, 0x56, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e,
+	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x22, 0x9b,
+	0x03, 0x0a, 0x09, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d,
+	0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0c, 0x68, 0x6f, 0x6d, 0x65, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x68, 0x6f, 0x6d, 0x65, 0x4c,
+	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x5f,
+	0x75, 0x6e, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x61, 0x73,
+	0x75, 0x72, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x75, 0x73, 0x65, 0x5f, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x75, 0x73, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x5f,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x72,
+	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x65, 0x6f, 0x66, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x67, 0x65, 0x6f, 0x66, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x25, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x56, 0x65, 0x6e, 0x75, 0x65, 0x52,
+	0x06, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x6f,
+	0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x7c, 0x0a, 0x10,
+	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x22, 0x8a, 0x01, 0x0a, 0x0f, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x74,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x5e, 0x0a, 0x0f, 0x56,
+	0x65, 0x6e, 0x75, 0x65, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x65, 0x6e, 0x75, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65,
+	0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x52, 0x09, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x22, 0xb0, 0x01, 0x0a, 0x09,
+	0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xe0,
+	0x02, 0x0a, 0x0a, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x31, 0x0a,
+	0x07, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x64,
+	0x12, 0x33, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x08, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65,
+	0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x06, 0x76,
+	0x65, 0x6e, 0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6e,
+	0x76, 0x69, 0x74, 0x65, 0x2e, 0x56, 0x65, 0x6e, 0x75, 0x65, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x69, 0x73, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x69, 0x73, 0x5f, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x69, 0x73, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x64, 0x75, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e,
+	0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x22, 0x6e, 0x0a, 0x0d, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x34, 0x0a, 0x09, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x4f, 0x66, 0x66, 0x69, 0x63, 0x65, 0x52, 0x09, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x73, 0x22, 0x81, 0x01, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x76, 0x69,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74,
+	0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x12, 0x34, 0x0a, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e,
+	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x4f, 0x66, 0x66, 0x69, 0x63, 0x65, 0x48, 0x00,
+	0x52, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb7, 0x01, 0x0a, 0x08, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x65, 0x6e, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x65, 0x6e, 0x75,
+	0x65, 0x12, 0x34, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x7d, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6e, 0x76, 0x69,
+	0x74, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x64,
+	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e,
+	0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x93,
+	0x01, 0x0a, 0x0d, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x33, 0x0a, 0x06, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x69, 0x6e, 0x76,
+	0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49,
+	0x6e, 0x76, 0x69, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x28, 0x01, 0x30, 0x01, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x68, 0x65, 0x6c, 0x6c, 0x67, 0x61, 0x74, 0x65, 0x37, 0x35, 0x2f, 0x67, 0x6f,
+	0x2d, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x2d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x73, 0x2f, 0x72, 0x70, 0x63, 0x3b, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_invite_proto_rawDescOnce sync.Once
+	file_invite_proto_rawDescData = file_invite_proto_rawDesc
+)
+
+func file_invite_proto_rawDescGZIP() []byte {
+	file_invite_proto_rawDescOnce.Do(func() {
+		file_invite_proto_rawDescData = protoimpl.X.CompressGZIP(file_invite_proto_rawDescData)
+	})
+	return file_invite_proto_rawDescData
+}
+
+var file_invite_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_invite_proto_goTypes = []interface{}{
+	(*CustomerOffice)(nil),       // 0: invite.CustomerOffice
+	(*Venue)(nil),                // 1: invite.Venue
+	(*InputData)(nil),            // 2: invite.InputData
+	(*CustomerLocation)(nil),     // 3: invite.CustomerLocation
+	(*CustomerDetails)(nil),      // 4: invite.CustomerDetails
+	(*RejectedCustomer)(nil),     // 5: invite.RejectedCustomer
+	(*VenueInviteList)(nil),      // 6: invite.VenueInviteList
+	(*ScanError)(nil),            // 7: invite.ScanError
+	(*OutputData)(nil),           // 8: invite.OutputData
+	(*InviteRequest)(nil),        // 9: invite.InviteRequest
+	(*StreamInviteRequest)(nil),  // 10: invite.StreamInviteRequest
+	(*Decision)(nil),             // 11: invite.Decision
+	(*StreamInviteResponse)(nil), // 12: invite.StreamInviteResponse
+}
+var file_invite_proto_depIdxs = []int32{
+	1,  // 0: invite.InputData.venues:type_name -> invite.Venue
+	3,  // 1: invite.CustomerDetails.location:type_name -> invite.CustomerLocation
+	4,  // 2: invite.VenueInviteList.customers:type_name -> invite.CustomerDetails
+	4,  // 3: invite.OutputData.invited:type_name -> invite.CustomerDetails
+	4,  // 4: invite.OutputData.excluded:type_name -> invite.CustomerDetails
+	5,  // 5: invite.OutputData.rejected:type_name -> invite.RejectedCustomer
+	6,  // 6: invite.OutputData.venues:type_name -> invite.VenueInviteList
+	7,  // 7: invite.OutputData.errors:type_name -> invite.ScanError
+	2,  // 8: invite.InviteRequest.input:type_name -> invite.InputData
+	0,  // 9: invite.InviteRequest.customers:type_name -> invite.CustomerOffice
+	2,  // 10: invite.StreamInviteRequest.input:type_name -> invite.InputData
+	0,  // 11: invite.StreamInviteRequest.customer:type_name -> invite.CustomerOffice
+	3,  // 12: invite.Decision.location:type_name -> invite.CustomerLocation
+	11, // 13: invite.StreamInviteResponse.decision:type_name -> invite.Decision
+	7,  // 14: invite.StreamInviteResponse.error:type_name -> invite.ScanError
+	9,  // 15: invite.InviteService.Invite:input_type -> invite.InviteRequest
+	10, // 16: invite.InviteService.StreamInvite:input_type -> invite.StreamInviteRequest
+	8,  // 17: invite.InviteService.Invite:output_type -> invite.OutputData
+	12, // 18: invite.InviteService.StreamInvite:output_type -> invite.StreamInviteResponse
+	17, // [17:19] is the sub-list for method output_type
+	15, // [15:17] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_invite_proto_init() }
+func file_invite_proto_init() {
+	if File_invite_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_invite_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CustomerOffice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Venue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InputData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CustomerLocation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CustomerDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectedCustomer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VenueInviteList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScanError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InviteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamInviteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Decision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invite_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamInviteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_invite_proto_msgTypes[10].OneofWrappers = []interface{}{
+		(*StreamInviteRequest_Input)(nil),
+		(*StreamInviteRequest_Customer)(nil),
+	}
+	file_invite_proto_msgTypes[12].OneofWrappers = []interface{}{
+		(*StreamInviteResponse_Decision)(nil),
+		(*StreamInviteResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_invite_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_invite_proto_goTypes,
+		DependencyIndexes: file_invite_proto_depIdxs,
+		MessageInfos:      file_invite_proto_msgTypes,
+	}.Build()
+	File_invite_proto = out.File
+	file_invite_proto_rawDesc = nil
+	file_invite_proto_goTypes = nil
+	file_invite_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// InviteServiceClient is the client API for InviteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InviteServiceClient interface {
+	// Scan a whole customers list and return the invitation lists
+	Invite(ctx context.Context, in *InviteRequest, opts ...grpc.CallOption) (*OutputData, error)
+	// Scan the customers as they are sent, the first request must carry the scan input data,
+	// the following ones a customer each. The invited and excluded decisions are streamed back
+	// as soon as they are made, the rejected ones only for the detailed output. The scan errors
+	// and then the duplicate records dropped by the first and last wins policies are streamed at
+	// the end of the scan.
+	StreamInvite(ctx context.Context, opts ...grpc.CallOption) (InviteService_StreamInviteClient, error)
+}
+
+type inviteServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInviteServiceClient(cc grpc.ClientConnInterface) InviteServiceClient {
+	return &inviteServiceClient{cc}
+}
+
+func (c *inviteServiceClient) Invite(ctx context.Context, in *InviteRequest, opts ...grpc.CallOption) (*OutputData, error) {
+	out := new(OutputData)
+	err := c.cc.Invoke(ctx, "/invite.InviteService/Invite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inviteServiceClient) StreamInvite(ctx context.Context, opts ...grpc.CallOption) (InviteService_StreamInviteClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_InviteService_serviceDesc.Streams[0], "/invite.InviteService/StreamInvite", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &inviteServiceStreamInviteClient{stream}
+	return x, nil
+}
+
+type InviteService_StreamInviteClient interface {
+	Send(*StreamInviteRequest) error
+	Recv() (*StreamInviteResponse, error)
+	grpc.ClientStream
+}
+
+type inviteServiceStreamInviteClient struct {
+	grpc.ClientStream
+}
+
+func (x *inviteServiceStreamInviteClient) Send(m *StreamInviteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *inviteServiceStreamInviteClient) Recv() (*StreamInviteResponse, error) {
+	m := new(StreamInviteResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// InviteServiceServer is the server API for InviteService service.
+type InviteServiceServer interface {
+	// Scan a whole customers list and return the invitation lists
+	Invite(context.Context, *InviteRequest) (*OutputData, error)
+	// Scan the customers as they are sent, the first request must carry the scan input data,
+	// the following ones a customer each. The invited and excluded decisions are streamed back
+	// as soon as they are made, the rejected ones only for the detailed output. The scan errors
+	// and then the duplicate records dropped by the first and last wins policies are streamed at
+	// the end of the scan.
+	StreamInvite(InviteService_StreamInviteServer) error
+}
+
+// UnimplementedInviteServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedInviteServiceServer struct {
+}
+
+func (*UnimplementedInviteServiceServer) Invite(context.Context, *InviteRequest) (*OutputData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Invite not implemented")
+}
+func (*UnimplementedInviteServiceServer) StreamInvite(InviteService_StreamInviteServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamInvite not implemented")
+}
+
+func RegisterInviteServiceServer(s *grpc.Server, srv InviteServiceServer) {
+	s.RegisterService(&_InviteService_serviceDesc, srv)
+}
+
+func _InviteService_Invite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InviteServiceServer).Invite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/invite.InviteService/Invite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InviteServiceServer).Invite(ctx, req.(*InviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InviteService_StreamInvite_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InviteServiceServer).StreamInvite(&inviteServiceStreamInviteServer{stream})
+}
+
+type InviteService_StreamInviteServer interface {
+	Send(*StreamInviteResponse) error
+	Recv() (*StreamInviteRequest, error)
+	grpc.ServerStream
+}
+
+type inviteServiceStreamInviteServer struct {
+	grpc.ServerStream
+}
+
+func (x *inviteServiceStreamInviteServer) Send(m *StreamInviteResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *inviteServiceStreamInviteServer) Recv() (*StreamInviteRequest, error) {
+	m := new(StreamInviteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _InviteService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "invite.InviteService",
+	HandlerType: (*InviteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Invite",
+			Handler:    _InviteService_Invite_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInvite",
+			Handler:       _InviteService_StreamInvite_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "invite.proto",
+}
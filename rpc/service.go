@@ -0,0 +1,317 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. invite.proto
+
+package rpc
+
+import (
+	"context"
+	"github.com/hellgate75/go-invite-customers/geo"
+	"github.com/hellgate75/go-invite-customers/invite"
+	"github.com/hellgate75/go-invite-customers/io"
+	"github.com/hellgate75/go-invite-customers/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	io2 "io"
+	"sync"
+)
+
+type inviteServer struct {
+	UnimplementedInviteServiceServer
+}
+
+//  Create the gRPC server of the invitation scans, to be registered with RegisterInviteServiceServer.
+//
+//  Each request carries its own scan input data, converted to invite.InputData, and the customers
+//  are evaluated by the invite package, as they are received for the streaming call.
+//
+//  The output is the invitation service server.
+func NewInviteServer() InviteServiceServer {
+	return &inviteServer{}
+}
+
+func (s *inviteServer) Invite(ctx context.Context, req *InviteRequest) (*OutputData, error) {
+	input, err := toInputData(req.GetInput())
+	if err != nil {
+		return nil, err
+	}
+	customers := make(chan model.CustomerOffice, len(req.GetCustomers()))
+	for _, customer := range req.GetCustomers() {
+		customers <- toCustomerOffice(customer)
+	}
+	close(customers)
+	out, errs := invite.ExecuteInviteScanFromChannel(ctx, customers, input)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return toOutputData(out, append(errs, out.DroppedDuplicates...)), nil
+}
+
+func (s *inviteServer) StreamInvite(stream InviteService_StreamInviteServer) error {
+	req, err := stream.Recv()
+	if err == io2.EOF {
+		return status.Error(codes.InvalidArgument, "Missing scan input data")
+	}
+	if err != nil {
+		return err
+	}
+	if req.GetInput() == nil {
+		return status.Error(codes.InvalidArgument, "The first request must carry the scan input data")
+	}
+	input, err := toInputData(req.GetInput())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	sink := &streamSink{stream: stream, detailed: input.UseDetailedOutput}
+	input.Sink = sink
+	customers := make(chan model.CustomerOffice)
+	var recvErr error
+	go func() {
+		// Signal the end of the customers to the scan, the error is read after the scan ends
+		defer close(customers)
+		for {
+			req, err := stream.Recv()
+			if err == io2.EOF {
+				return
+			}
+			if err == nil && req.GetCustomer() == nil {
+				err = status.Error(codes.InvalidArgument, "The requests after the first one must carry a customer")
+			}
+			if err != nil {
+				recvErr = err
+				cancel()
+				return
+			}
+			select {
+			case customers <- toCustomerOffice(req.GetCustomer()):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	out, errs := invite.ExecuteInviteScanFromChannel(ctx, customers, input)
+	// The scan ends after the customers channel is closed, so the receive error is set
+	for range customers {
+	}
+	if recvErr != nil {
+		return recvErr
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	for _, scanErr := range append(errs, out.DroppedDuplicates...) {
+		sink.send(&StreamInviteResponse{
+			Response: &StreamInviteResponse_Error{Error: toScanError(scanErr)},
+		})
+	}
+	return sink.Err()
+}
+
+// Sink sending each decision to the stream as soon as it is made
+type streamSink struct {
+	m        sync.Mutex
+	stream   InviteService_StreamInviteServer
+	detailed bool
+	err      error
+}
+
+func (s *streamSink) Invited(customer model.CustomerDetails) {
+	s.sendDecision(&Decision{
+		Decision: io.InvitedDecision,
+		UserId:   customer.UserId,
+		Name:     customer.Name,
+		Venue:    customer.Venue,
+		Location: toCustomerLocation(customer.Location),
+	})
+}
+
+func (s *streamSink) Excluded(customer model.CustomerDetails) {
+	s.sendDecision(&Decision{
+		Decision: io.ExcludedDecision,
+		UserId:   customer.UserId,
+		Name:     customer.Name,
+		Location: toCustomerLocation(customer.Location),
+	})
+}
+
+func (s *streamSink) Rejected(customer model.CustomerOffice, reason error) {
+	if s.detailed {
+		decision := &Decision{
+			Decision: io.RejectedDecision,
+			UserId:   customer.UserId,
+			Name:     customer.Name,
+		}
+		if reason != nil {
+			decision.Reason = reason.Error()
+		}
+		s.sendDecision(decision)
+	}
+}
+
+// Get the first error arisen sending the responses, if any
+func (s *streamSink) Err() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.err
+}
+
+func (s *streamSink) sendDecision(decision *Decision) {
+	s.send(&StreamInviteResponse{
+		Response: &StreamInviteResponse_Decision{Decision: decision},
+	})
+}
+
+func (s *streamSink) send(resp *StreamInviteResponse) {
+	// The stream does not support concurrent sends by the evaluation workers
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.err != nil {
+		return
+	}
+	s.err = s.stream.Send(resp)
+}
+
+// Convert the request input data, reporting the invalid arguments
+func toInputData(in *InputData) (input invite.InputData, err error) {
+	if in == nil {
+		return input, status.Error(codes.InvalidArgument, "Missing scan input data")
+	}
+	if in.GetHomeLatitude() < -90 || in.GetHomeLatitude() > 90 || in.GetHomeLongitude() < -180 || in.GetHomeLongitude() > 180 {
+		return input, status.Errorf(codes.InvalidArgument, "Invalid home coordinates: %v, %v", in.GetHomeLatitude(), in.GetHomeLongitude())
+	}
+	if in.GetDistance() <= 0 {
+		return input, status.Error(codes.InvalidArgument, "Distance cannot be zero or less")
+	}
+	input.HomeLatitude = in.GetHomeLatitude()
+	input.HomeLongitude = in.GetHomeLongitude()
+	input.Distance = in.GetDistance()
+	input.UseDetailedOutput = in.GetUseDetailedOutput()
+	input.WorkerPoolSize = int(in.GetWorkerPoolSize())
+	// Kilometers are the default unit, as for the command line
+	input.MeasureUnit = geo.Kilometers
+	if in.GetMeasureUnit() != "" {
+		if input.MeasureUnit, err = geo.ParseUnit(in.GetMeasureUnit()); err != nil {
+			return input, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if input.SortOrder, err = model.ToSortOrder(in.GetSortOrder()); err != nil {
+		return input, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if input.Algorithm, err = geo.ToAlgorithm(in.GetAlgorithm()); err != nil {
+		return input, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if input.DuplicatePolicy, err = model.ToDuplicatePolicy(in.GetDuplicatePolicy()); err != nil {
+		return input, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(in.GetGeofence()) > 0 {
+		if input.Geofence, err = geo.ReadGeofence(in.GetGeofence()); err != nil {
+			return input, status.Errorf(codes.InvalidArgument, "Invalid geofence: %v", err)
+		}
+	}
+	for _, venue := range in.GetVenues() {
+		input.Venues = append(input.Venues, model.Venue{
+			Name:      venue.GetName(),
+			Latitude:  venue.GetLatitude(),
+			Longitude: venue.GetLongitude(),
+			Radius:    venue.GetRadius(),
+		})
+	}
+	return input, nil
+}
+
+func toCustomerOffice(customer *CustomerOffice) model.CustomerOffice {
+	return model.CustomerOffice{
+		UserId:    customer.GetUserId(),
+		Name:      customer.GetName(),
+		Latitude:  customer.GetLatitude(),
+		Longitude: customer.GetLongitude(),
+	}
+}
+
+// Convert the scan output data, the excluded and rejected customers are reported by the detailed output
+func toOutputData(out invite.OutputData, errs []error) *OutputData {
+	data := &OutputData{
+		IsComplete: out.IsComplete,
+		IsDone:     out.IsDone,
+		Duplicates: int32(out.Duplicates),
+	}
+	var venues []model.VenueInviteList
+	if out.IsComplete {
+		data.Invited = toCustomerDetailsList(out.Complete.MatchingCustomerIds)
+		data.Excluded = toCustomerDetailsList(out.Complete.UnMatchingCustomerIds)
+		for _, customer := range out.Complete.RejectedCustomerIds {
+			data.Rejected = append(data.Rejected, &RejectedCustomer{
+				UserId:    customer.UserId,
+				Name:      customer.Name,
+				Latitude:  customer.Latitude,
+				Longitude: customer.Longitude,
+				Reason:    customer.Reason,
+			})
+		}
+		venues = out.Complete.Venues
+	} else {
+		data.Invited = toCustomerDetailsList(out.Simple.CustomerIds)
+		venues = out.Simple.Venues
+	}
+	for _, group := range venues {
+		data.Venues = append(data.Venues, &VenueInviteList{
+			Venue:     group.Venue,
+			Customers: toCustomerDetailsList(group.CustomerIds),
+		})
+	}
+	for _, err := range errs {
+		data.Errors = append(data.Errors, toScanError(err))
+	}
+	return data
+}
+
+func toCustomerDetailsList(customers []model.CustomerDetails) []*CustomerDetails {
+	list := make([]*CustomerDetails, 0, len(customers))
+	for _, customer := range customers {
+		list = append(list, &CustomerDetails{
+			UserId:   customer.UserId,
+			Name:     customer.Name,
+			Location: toCustomerLocation(customer.Location),
+			Venue:    customer.Venue,
+		})
+	}
+	return list
+}
+
+func toCustomerLocation(location *model.CustomerLocation) *CustomerLocation {
+	if location == nil {
+		return nil
+	}
+	return &CustomerLocation{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+		Distance:  location.Distance,
+		Unit:      location.Unit,
+	}
+}
+
+func toScanError(err error) *ScanError {
+	record := model.ToErrorRecord(err)
+	scanError := &ScanError{
+		Kind:    string(record.Kind),
+		Message: record.Message,
+		Line:    int32(record.Line),
+		Snippet: record.Snippet,
+		Source:  record.Source,
+	}
+	if record.Offset != nil {
+		scanError.Offset = *record.Offset
+	}
+	if record.UserId != nil {
+		scanError.UserId = *record.UserId
+	}
+	return scanError
+}
@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package rpc
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"sort"
+	"testing"
+)
+
+var testCustomers = []*CustomerOffice{
+	{UserId: 12, Name: "Thomas Barret", Latitude: "53.339111", Longitude: "-6.257611"},
+	{UserId: 1, Name: "Michael Barret", Latitude: "51.903614", Longitude: "-8.468399"},
+	{UserId: 2, Name: "Mark Barret", Latitude: "95", Longitude: "-8.468399"},
+}
+
+// Start the service on an in-process listener, returning the connected client
+func startTestServer(t *testing.T) (InviteServiceClient, func()) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	RegisterInviteServiceServer(server, NewInviteServer())
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	return NewInviteServiceClient(conn), func() {
+		_ = conn.Close()
+		server.Stop()
+	}
+}
+
+func TestInviteServer_Invite(t *testing.T) {
+	client, stop := startTestServer(t)
+	defer stop()
+	tests := []struct {
+		name         string
+		input        *InputData
+		wantCode     codes.Code
+		wantInvited  []int64
+		wantExcluded []int64
+		wantRejected []int64
+		wantErrors   int
+	}{
+		{
+			name:        "Test simple output",
+			input:       &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664, Distance: 100, MeasureUnit: "K"},
+			wantCode:    codes.OK,
+			wantInvited: []int64{12},
+			wantErrors:  1,
+		},
+		{
+			name:         "Test detailed output sorted by user id",
+			input:        &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664, Distance: 100, UseDetailedOutput: true, SortOrder: "user-id"},
+			wantCode:     codes.OK,
+			wantInvited:  []int64{12},
+			wantExcluded: []int64{1},
+			wantRejected: []int64{2},
+			wantErrors:   1,
+		},
+		{
+			name: "Test venues output",
+			input: &InputData{Distance: 1, Venues: []*Venue{
+				{Name: "Cork", Latitude: 51.903614, Longitude: -8.468399, Radius: 10},
+				{Name: "Dublin", Latitude: 53.339428, Longitude: -6.257664, Radius: 10},
+			}},
+			wantCode:    codes.OK,
+			wantInvited: []int64{1, 12},
+			wantErrors:  1,
+		},
+		{
+			name:     "Test missing input",
+			input:    nil,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Test invalid distance",
+			input:    &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Test invalid unit",
+			input:    &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664, Distance: 100, MeasureUnit: "parsec"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Test invalid duplicate policy",
+			input:    &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664, Distance: 100, DuplicatePolicy: "merge"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Test invalid geofence",
+			input:    &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664, Distance: 100, Geofence: []byte("{bad}")},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := client.Invite(context.Background(), &InviteRequest{Input: tt.input, Customers: testCustomers})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Invite() error = %v, want code %v", err, tt.wantCode)
+				return
+			}
+			if err != nil {
+				return
+			}
+			invited := out.GetInvited()
+			for _, group := range out.GetVenues() {
+				invited = append(invited, group.GetCustomers()...)
+			}
+			if got := userIds(invited); !equalIds(got, tt.wantInvited) {
+				t.Errorf("Invite() invited = %v, want %v", got, tt.wantInvited)
+			}
+			if got := userIds(out.GetExcluded()); !equalIds(got, tt.wantExcluded) {
+				t.Errorf("Invite() excluded = %v, want %v", got, tt.wantExcluded)
+			}
+			rejected := make([]int64, 0)
+			for _, customer := range out.GetRejected() {
+				rejected = append(rejected, customer.GetUserId())
+			}
+			if !equalIds(rejected, tt.wantRejected) {
+				t.Errorf("Invite() rejected = %v, want %v", rejected, tt.wantRejected)
+			}
+			if len(out.GetErrors()) != tt.wantErrors {
+				t.Errorf("Invite() errors = %v, want %v errors", out.GetErrors(), tt.wantErrors)
+			}
+			if out.GetIsDone() != (tt.wantErrors == 0) {
+				t.Errorf("Invite() is done = %v, want %v", out.GetIsDone(), tt.wantErrors == 0)
+			}
+		})
+	}
+}
+
+func TestInviteServer_StreamInvite(t *testing.T) {
+	client, stop := startTestServer(t)
+	defer stop()
+	customerRequest := func(customer *CustomerOffice) *StreamInviteRequest {
+		return &StreamInviteRequest{Request: &StreamInviteRequest_Customer{Customer: customer}}
+	}
+	tests := []struct {
+		name          string
+		input         *InputData
+		customers     []*CustomerOffice
+		wantCode      codes.Code
+		wantDecisions map[int64]string
+		wantErrors    []string
+	}{
+		{
+			name:          "Test simple output streams invited and excluded",
+			input:         &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664, Distance: 100},
+			customers:     testCustomers,
+			wantCode:      codes.OK,
+			wantDecisions: map[int64]string{12: "invited", 1: "excluded"},
+			wantErrors:    []string{"coordinate"},
+		},
+		{
+			name:          "Test detailed output streams all decisions",
+			input:         &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664, Distance: 100, UseDetailedOutput: true},
+			customers:     testCustomers,
+			wantCode:      codes.OK,
+			wantDecisions: map[int64]string{12: "invited", 1: "excluded", 2: "rejected"},
+			wantErrors:    []string{"coordinate"},
+		},
+		{
+			name:  "Test duplicate customers",
+			input: &InputData{HomeLatitude: 53.339428, HomeLongitude: -6.257664, Distance: 100},
+			customers: []*CustomerOffice{
+				testCustomers[0],
+				{UserId: 12, Name: "Tom Barret", Latitude: "53.339111", Longitude: "-6.257611"},
+			},
+			wantCode:      codes.OK,
+			wantDecisions: map[int64]string{12: "invited"},
+			wantErrors:    []string{"duplicate"},
+		},
+		{
+			name:     "Test missing input",
+			input:    nil,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Test invalid input",
+			input:    &InputData{HomeLatitude: 91, HomeLongitude: -6.257664, Distance: 100},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.StreamInvite(context.Background())
+			if err != nil {
+				t.Errorf("StreamInvite() error = %v", err)
+				return
+			}
+			if tt.input != nil {
+				_ = stream.Send(&StreamInviteRequest{Request: &StreamInviteRequest_Input{Input: tt.input}})
+			} else {
+				_ = stream.Send(customerRequest(testCustomers[0]))
+			}
+			for _, customer := range tt.customers {
+				_ = stream.Send(customerRequest(customer))
+			}
+			_ = stream.CloseSend()
+			decisions := make(map[int64]string)
+			errs := make([]string, 0)
+			for {
+				resp, errR := stream.Recv()
+				if errR == io.EOF {
+					break
+				}
+				if errR != nil {
+					err = errR
+					break
+				}
+				if decision := resp.GetDecision(); decision != nil {
+					decisions[decision.GetUserId()] = decision.GetDecision()
+				}
+				if scanError := resp.GetError(); scanError != nil {
+					errs = append(errs, scanError.GetKind())
+				}
+			}
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("StreamInvite() error = %v, want code %v", err, tt.wantCode)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if len(decisions) != len(tt.wantDecisions) {
+				t.Errorf("StreamInvite() decisions = %v, want %v", decisions, tt.wantDecisions)
+			}
+			for userId, decision := range tt.wantDecisions {
+				if decisions[userId] != decision {
+					t.Errorf("StreamInvite() decision [%v] = %v, want %v", userId, decisions[userId], decision)
+				}
+			}
+			if len(errs) != len(tt.wantErrors) {
+				t.Errorf("StreamInvite() errors = %v, want %v", errs, tt.wantErrors)
+				return
+			}
+			for i, kind := range tt.wantErrors {
+				if errs[i] != kind {
+					t.Errorf("StreamInvite() error kind = %v, want %v", errs[i], kind)
+				}
+			}
+		})
+	}
+}
+
+func userIds(customers []*CustomerDetails) []int64 {
+	ids := make([]int64, 0)
+	for _, customer := range customers {
+		ids = append(ids, customer.GetUserId())
+	}
+	return ids
+}
+
+func equalIds(got []int64, want []int64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hellgate75/go-invite-customers/geo"
+	"github.com/hellgate75/go-invite-customers/invite"
+	"github.com/hellgate75/go-invite-customers/io"
+	io2 "io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Path of the invitation scan endpoint
+const InvitePath = "/invite"
+
+// Response header reporting the number of the processing errors of the scan
+const ErrorsHeader = "X-Invite-Errors"
+
+// Media type of the newline-delimited json input, read one customer per line
+const ndjsonMediaType = "application/x-ndjson"
+
+// Max size of the request body
+const maxRequestSize = 32 << 20
+
+// Error of a request that cannot be served, with the http status code of the response
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+// Request body reader, recording the bytes read and the read error other than the end of file
+type countingReader struct {
+	reader io2.Reader
+	count  int64
+	err    error
+}
+
+func (r *countingReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.count += int64(n)
+	if err != nil && err != io2.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+type inviteHandler struct {
+	defaults invite.InputData
+}
+
+//  Create the http handler serving the invitation scans at the POST /invite endpoint.
+//
+//  The request body is the customers list, in one of the io.InputEncoding encodings chosen by
+//  the Content-Type header (application/x-ndjson is read one json customer per line). The
+//  latitude, longitude, distance, unit and detailed query parameters replace the default ones.
+//  The response is encoded in the io.OutputEncoding encoding negotiated by the Accept header,
+//  json if any is accepted, and the X-Invite-Errors header reports the processing errors count.
+//  A body that cannot be read to its end fails the request with 400, or 413 if it is larger
+//  than 32 MB, and a scan interrupted by the request cancellation fails it with 503.
+//
+//  Defaults/
+//  Default scan parameters, e.g. the venues, the geofence and the distance algorithm
+//
+//  The output is the http handler.
+func NewInviteHandler(defaults invite.InputData) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(InvitePath, &inviteHandler{defaults})
+	return mux
+}
+
+func (h *inviteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, fmt.Sprintf("Method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	input, err := h.requestInput(r)
+	if err != nil {
+		var reqErr *requestError
+		if errors.As(err, &reqErr) {
+			http.Error(w, reqErr.Error(), reqErr.status)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+	body := &countingReader{reader: http.MaxBytesReader(w, r.Body, maxRequestSize)}
+	out, errs := invite.ExecuteInviteScanFromReader(r.Context(), body, input)
+	if body.err != nil && body.count >= maxRequestSize {
+		// The body has been cut by the size limit, so the scan results are partial
+		http.Error(w, fmt.Sprintf("Request body larger than %v bytes", maxRequestSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if body.err != nil {
+		// The body cannot be read to its end, e.g. a client reset or a truncated chunked body
+		http.Error(w, fmt.Sprintf("Error reading request body: %v", body.err), http.StatusBadRequest)
+		return
+	}
+	if err = r.Context().Err(); err != nil {
+		// The scan has been interrupted, so the scan results are partial
+		http.Error(w, fmt.Sprintf("Scan interrupted: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	data, err := invite.EncodeOutputData(out, input)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error converting output: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", io.ContentType(input.OutputEncoding))
+	w.Header().Set(ErrorsHeader, strconv.Itoa(len(errs)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// Build the scan input data from the defaults and the request headers and query parameters
+func (h *inviteHandler) requestInput(r *http.Request) (input invite.InputData, err error) {
+	input = h.defaults
+	input.FileOrStream = ""
+	input.Sink = nil
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return input, &requestError{http.StatusUnsupportedMediaType, errors.New("Missing Content-Type header")}
+	}
+	if mediaType, _, errM := mime.ParseMediaType(contentType); errM == nil && mediaType == ndjsonMediaType {
+		input.InputEncoding = io.JsonEncoding
+		input.UsePerLineInput = true
+	} else {
+		enc, errE := io.ToContentTypeEncoding(contentType)
+		if errE != nil || !hasEncoding(io.InputEncoding, enc) {
+			return input, &requestError{http.StatusUnsupportedMediaType, errors.New(fmt.Sprintf("Unsupported Content-Type: %s", contentType))}
+		}
+		input.InputEncoding = enc
+		input.UsePerLineInput = false
+	}
+	if input.OutputEncoding, err = negotiateEncoding(r.Header.Get("Accept")); err != nil {
+		return input, &requestError{http.StatusNotAcceptable, err}
+	}
+	query := r.URL.Query()
+	parameters := []struct {
+		name  string
+		value *float64
+	}{
+		{"latitude", &input.HomeLatitude},
+		{"longitude", &input.HomeLongitude},
+		{"distance", &input.Distance},
+	}
+	for _, parameter := range parameters {
+		name, value := parameter.name, parameter.value
+		if text := query.Get(name); text != "" {
+			if *value, err = strconv.ParseFloat(text, 64); err != nil {
+				return input, errors.New(fmt.Sprintf("Invalid %s parameter: %s", name, text))
+			}
+		}
+	}
+	if input.HomeLatitude < -90 || input.HomeLatitude > 90 || input.HomeLongitude < -180 || input.HomeLongitude > 180 {
+		return input, errors.New(fmt.Sprintf("Invalid home coordinates: %v, %v", input.HomeLatitude, input.HomeLongitude))
+	}
+	if input.Distance <= 0 {
+		return input, errors.New("Distance cannot be zero or less")
+	}
+	if text := query.Get("unit"); text != "" {
+		var unit geo.Unit
+		if unit, err = geo.ParseUnit(text); err != nil {
+			return input, err
+		}
+		input.MeasureUnit = unit
+	}
+	if text := query.Get("detailed"); text != "" {
+		if input.UseDetailedOutput, err = strconv.ParseBool(text); err != nil {
+			return input, errors.New(fmt.Sprintf("Invalid detailed parameter: %s", text))
+		}
+	}
+	if input.OutputEncoding == io.GeoJsonEncoding {
+		// Map points need the customers location, reported by the detailed output
+		input.UseDetailedOutput = true
+	}
+	return input, nil
+}
+
+//  Choose the output encoding accepted with the highest quality, json when any encoding is
+//  accepted or the Accept header is missing, the first listed one among equal qualities.
+func negotiateEncoding(accept string) (io.Encoding, error) {
+	if strings.TrimSpace(accept) == "" {
+		return io.JsonEncoding, nil
+	}
+	best, bestQuality := io.UnknownEncoding, 0.0
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(item)
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= bestQuality {
+			continue
+		}
+		if enc := acceptedEncoding(mediaType); enc != io.UnknownEncoding {
+			best, bestQuality = enc, quality
+		}
+	}
+	if best == io.UnknownEncoding {
+		return best, errors.New(fmt.Sprintf("No supported media type in Accept header: %s, available encodings: %v", accept, io.OutputEncoding))
+	}
+	return best, nil
+}
+
+// Output encoding of the accepted media type, that can be a type/* or */* range
+func acceptedEncoding(mediaType string) io.Encoding {
+	if mediaType == "*/*" {
+		return io.JsonEncoding
+	}
+	if strings.HasSuffix(mediaType, "/*") {
+		for _, name := range io.OutputEncoding {
+			enc, _ := io.ToEncoding(name)
+			if strings.HasPrefix(io.ContentType(enc), strings.TrimSuffix(mediaType, "*")) {
+				return enc
+			}
+		}
+		return io.UnknownEncoding
+	}
+	if enc, err := io.ToContentTypeEncoding(mediaType); err == nil && hasEncoding(io.OutputEncoding, enc) {
+		return enc
+	}
+	return io.UnknownEncoding
+}
+
+func hasEncoding(names []string, enc io.Encoding) bool {
+	for _, name := range names {
+		if candidate, _ := io.ToEncoding(name); candidate == enc {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2020. This application code is under GNU Lesser General Public License, available here:
+ * https://www.gnu.org/licenses/lgpl-3.0-standalone.html
+ *
+ * Any change or alterations are forbidden under the name of the author without any prior authorization, any abuse will be persecuted accordingly to the International Copyright Laws.
+ * You can contact the author Fabrizio Torelli via email: hellgate75@gmail.com or using LinkedIn profile: https://www.linkedin.com/in/fabriziotorelli
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/hellgate75/go-invite-customers/invite"
+	"github.com/hellgate75/go-invite-customers/io"
+	io2 "io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewInviteHandler(t *testing.T) {
+	server := httptest.NewServer(NewInviteHandler(invite.InputData{
+		HomeLatitude:  53.339428,
+		HomeLongitude: -6.257664,
+		Distance:      100,
+		MeasureUnit:   "K",
+	}))
+	defer server.Close()
+	jsonList := `{"customers": [` +
+		`{"latitude": "53.339111", "user_id": 12, "name": "Thomas Barret", "longitude": "-6.257611"},` +
+		`{"latitude": "51.903614", "user_id": 1, "name": "Michael Barret", "longitude": "-8.468399"}]}`
+	tests := []struct {
+		name            string
+		method          string
+		query           string
+		contentType     string
+		accept          string
+		body            string
+		wantStatus      int
+		wantContentType string
+		wantErrors      string
+		wantBody        []string
+	}{
+		{
+			name:            "Test json list with default json output",
+			method:          http.MethodPost,
+			contentType:     "application/json",
+			body:            jsonList,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantErrors:      "0",
+			wantBody:        []string{`"user_id":12`},
+		},
+		{
+			name:            "Test query distance and csv output",
+			method:          http.MethodPost,
+			query:           "?distance=300&unit=k",
+			contentType:     "application/json",
+			accept:          "text/html;q=0.5, text/csv",
+			body:            jsonList,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantErrors:      "0",
+			wantBody:        []string{"user_id,name\n12,Thomas Barret\n1,Michael Barret\n"},
+		},
+		{
+			name:            "Test query home coordinates and detailed output",
+			method:          http.MethodPost,
+			query:           "?latitude=51.903614&longitude=-8.468399&distance=10&detailed=true",
+			contentType:     "application/json",
+			accept:          "text/plain",
+			body:            jsonList,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantErrors:      "0",
+			wantBody:        []string{"Invite Summary:\n[1] Michael Barret - distance: 0.000 K", "Exclusion Summary:\n[12] Thomas Barret - distance: 218.488 K"},
+		},
+		{
+			name:            "Test ndjson input with parse errors",
+			method:          http.MethodPost,
+			contentType:     "application/x-ndjson",
+			accept:          "application/x-ndjson",
+			body:            "{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}\n{bad}\n",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantErrors:      "1",
+			wantBody:        []string{"{\"decision\":\"invited\",\"user_id\":12,\"name\":\"Thomas Barret\"}\n"},
+		},
+		{
+			name:            "Test csv input with yaml output",
+			method:          http.MethodPost,
+			contentType:     "text/csv; charset=utf-8",
+			accept:          "application/yaml",
+			body:            "user_id,name,latitude,longitude\n12,Thomas Barret,53.339111,-6.257611\n",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-yaml",
+			wantErrors:      "0",
+			wantBody:        []string{"user_id: 12"},
+		},
+		{
+			name:            "Test geojson output",
+			method:          http.MethodPost,
+			contentType:     "application/json",
+			accept:          "application/geo+json",
+			body:            jsonList,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/geo+json",
+			wantErrors:      "0",
+			wantBody:        []string{`"FeatureCollection"`, `"status":"excluded"`},
+		},
+		{
+			name:        "Test body too large",
+			method:      http.MethodPost,
+			contentType: "application/x-ndjson",
+			body:        strings.Repeat("{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}\n", maxRequestSize/80+1),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "Test method not allowed",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "Test unsupported content type",
+			method:      http.MethodPost,
+			contentType: "text/html",
+			body:        "<html></html>",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "Test not acceptable output",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			accept:      "image/png",
+			body:        jsonList,
+			wantStatus:  http.StatusNotAcceptable,
+		},
+		{
+			name:        "Test invalid distance",
+			method:      http.MethodPost,
+			query:       "?distance=-1",
+			contentType: "application/json",
+			body:        jsonList,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "Test invalid unit",
+			method:      http.MethodPost,
+			query:       "?unit=parsec",
+			contentType: "application/json",
+			body:        jsonList,
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+InvitePath+tt.query, strings.NewReader(tt.body))
+			if err != nil {
+				t.Errorf("NewRequest() error = %v", err)
+				return
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("Do() error = %v", err)
+				return
+			}
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			data, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("POST %s status = %v, want %v, body %s", InvitePath, resp.StatusCode, tt.wantStatus, data)
+				return
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := resp.Header.Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("POST %s Content-Type = %v, want %v", InvitePath, got, tt.wantContentType)
+			}
+			if got := resp.Header.Get(ErrorsHeader); got != tt.wantErrors {
+				t.Errorf("POST %s %s = %v, want %v", InvitePath, ErrorsHeader, got, tt.wantErrors)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("POST %s body = %s, want to contain %s", InvitePath, data, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_negotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		accept  string
+		wantEnc io.Encoding
+		wantErr bool
+	}{
+		{"Negotiate missing header", "", io.JsonEncoding, false},
+		{"Negotiate any media type", "*/*", io.JsonEncoding, false},
+		{"Negotiate media type range", "text/*", io.TextEncoding, false},
+		{"Negotiate highest quality", "application/xml;q=0.2, text/markdown;q=0.9, */*;q=0.1", io.MarkdownEncoding, false},
+		{"Negotiate first among equal qualities", "text/html, application/json", io.HtmlEncoding, false},
+		{"Negotiate skipping unsupported media types", "image/png, application/x-ndjson", io.NdjsonEncoding, false},
+		{"Negotiate excluded media type", "application/json;q=0", io.UnknownEncoding, true},
+		{"Negotiate input only media type", "text/tab-separated-values", io.UnknownEncoding, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEnc, err := negotiateEncoding(tt.accept)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("negotiateEncoding() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotEnc != tt.wantEnc {
+				t.Errorf("negotiateEncoding() gotEnc = %v, want %v", gotEnc, tt.wantEnc)
+			}
+		})
+	}
+}
+
+// Request body failing after the given data
+type brokenBody struct {
+	data io2.Reader
+}
+
+func (b *brokenBody) Read(p []byte) (int, error) {
+	n, err := b.data.Read(p)
+	if err == io2.EOF {
+		return n, errors.New("connection reset by peer")
+	}
+	return n, err
+}
+
+func TestNewInviteHandler_partialScan(t *testing.T) {
+	handler := NewInviteHandler(invite.InputData{
+		HomeLatitude:  53.339428,
+		HomeLongitude: -6.257664,
+		Distance:      100,
+		MeasureUnit:   "K",
+	})
+	line := "{\"latitude\": \"53.339111\", \"user_id\": 12, \"name\": \"Thomas Barret\", \"longitude\": \"-6.257611\"}\n"
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		body       io2.Reader
+		wantStatus int
+	}{
+		{
+			name:       "Test broken request body",
+			ctx:        context.Background(),
+			body:       &brokenBody{data: strings.NewReader(line)},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Test cancelled request",
+			ctx:        cancelled,
+			body:       strings.NewReader(line),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, InvitePath, tt.body).WithContext(tt.ctx)
+			req.Header.Set("Content-Type", "application/x-ndjson")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("POST %s status = %v, want %v, body %s", InvitePath, rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}